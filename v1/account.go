@@ -1,9 +1,8 @@
 package payjp
 
 import (
+	"context"
 	"encoding/json"
-	"io/ioutil"
-	"net/http"
 	"time"
 )
 
@@ -18,24 +17,25 @@ func newAccountService(service *Service) *AccountService {
 
 // Retrieve account object. あなたのアカウント情報を取得します。
 func (t *AccountService) Retrieve() (*AccountResponse, error) {
-	request, err := http.NewRequest("GET", t.service.apiBase+"/accounts", nil)
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Add("Authorization", t.service.apiKey)
+	return t.RetrieveWithContext(context.Background())
+}
 
-	resp, err := t.service.Client.Do(request)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
-	result := &AccountResponse{}
-	err = json.Unmarshal(body, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
+// RetrieveWithContext はcontext.Contextを指定してアカウント情報を取得します。
+func (t *AccountService) RetrieveWithContext(ctx context.Context) (*AccountResponse, error) {
+	result, err := t.service.call(ctx, "account.retrieve", "", func(ctx context.Context) (interface{}, error) {
+		body, err := t.service.retrieve(ctx, "/accounts")
+		if err != nil {
+			return nil, err
+		}
+		result := &AccountResponse{}
+		err = json.Unmarshal(body, result)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
+	response, _ := result.(*AccountResponse)
+	return response, err
 }
 
 // AccountResponse はAccount.Retrieve()メソッドが返す構造体です
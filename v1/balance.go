@@ -0,0 +1,337 @@
+package payjp
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// BalanceState は残高の状態を示すステータスです
+type BalanceState int
+
+const (
+	noBalanceState BalanceState = iota
+	// BalanceCollecting は集計中の状態を表す定数
+	BalanceCollecting
+	// BalanceTransfer は入金予定の状態を表す定数
+	BalanceTransfer
+	// BalanceClaim は請求予定の状態を表す定数
+	BalanceClaim
+)
+
+func parseBalanceState(state string) BalanceState {
+	switch state {
+	case "collecting":
+		return BalanceCollecting
+	case "transfer":
+		return BalanceTransfer
+	case "claim":
+		return BalanceClaim
+	}
+	return noBalanceState
+}
+
+func (b BalanceState) status() interface{} {
+	switch b {
+	case BalanceCollecting:
+		return "collecting"
+	case BalanceTransfer:
+		return "transfer"
+	case BalanceClaim:
+		return "claim"
+	}
+	return nil
+}
+
+// BalanceService は残高に関するサービスです。
+//
+// 残高は集計区間ごとの取引明細をまとめたもので、入金または請求の単位になります。
+type BalanceService struct {
+	service *Service
+}
+
+func newBalanceService(service *Service) *BalanceService {
+	return &BalanceService{
+		service: service,
+	}
+}
+
+// Retrieve は残高を取得します。
+func (b BalanceService) Retrieve(id string) (*BalanceResponse, error) {
+	return b.RetrieveWithContext(context.Background(), id)
+}
+
+// RetrieveWithContext はcontext.Contextを指定して残高を取得します。
+func (b BalanceService) RetrieveWithContext(ctx context.Context, id string) (*BalanceResponse, error) {
+	result, err := b.service.call(ctx, "balance.retrieve", id, func(ctx context.Context) (interface{}, error) {
+		body, err := b.service.retrieve(ctx, "/balances/"+id)
+		if err != nil {
+			return nil, err
+		}
+		result := &BalanceResponse{}
+		err = json.Unmarshal(body, result)
+		if err != nil {
+			return nil, err
+		}
+		result.setService(b.service)
+		return result, nil
+	})
+	response, _ := result.(*BalanceResponse)
+	return response, err
+}
+
+// List は残高のリストを取得します。リストは、直近で生成された順番に取得されます。
+func (b BalanceService) List() *BalanceListCaller {
+	return &BalanceListCaller{
+		state:   noBalanceState,
+		service: b.service,
+	}
+}
+
+// BalanceListCaller は残高のリスト取得に使用する構造体です。
+type BalanceListCaller struct {
+	service  *Service
+	limit    int
+	offset   int
+	since    int
+	until    int
+	owner    string
+	state    BalanceState
+	closed   *bool
+	tenantID string
+}
+
+// Limit はリストの要素数の最大値を設定します(1-100)
+func (c *BalanceListCaller) Limit(limit int) *BalanceListCaller {
+	c.limit = limit
+	return c
+}
+
+// Offset は取得するリストの先頭要素のインデックスのオフセットを設定します
+func (c *BalanceListCaller) Offset(offset int) *BalanceListCaller {
+	c.offset = offset
+	return c
+}
+
+// Since はここに指定したタイムスタンプ以降に作成されたデータを取得します
+func (c *BalanceListCaller) Since(since time.Time) *BalanceListCaller {
+	c.since = int(since.Unix())
+	return c
+}
+
+// Until はここに指定したタイムスタンプ以前に作成されたデータを取得します
+func (c *BalanceListCaller) Until(until time.Time) *BalanceListCaller {
+	c.until = int(until.Unix())
+	return c
+}
+
+// Owner を指定すると、指定した所有者の残高のみを取得します("merchant"または"tenant")
+func (c *BalanceListCaller) Owner(owner string) *BalanceListCaller {
+	c.owner = owner
+	return c
+}
+
+// State を指定すると、指定した状態の残高のみを取得します
+func (c *BalanceListCaller) State(state BalanceState) *BalanceListCaller {
+	c.state = state
+	return c
+}
+
+// Closed を指定すると、精算済み(true)または未精算(false)の残高のみを取得します
+func (c *BalanceListCaller) Closed(closed bool) *BalanceListCaller {
+	c.closed = &closed
+	return c
+}
+
+// TenantID を指定すると、指定したテナントの残高のみを取得します(PAY.JP Platformのみ)
+func (c *BalanceListCaller) TenantID(id string) *BalanceListCaller {
+	c.tenantID = id
+	return c
+}
+
+// Do は指定されたクエリーを元に残高のリストを配列で取得します。
+func (c *BalanceListCaller) Do() ([]*BalanceResponse, bool, error) {
+	return c.DoContext(context.Background())
+}
+
+// DoContext はcontext.Contextを指定して残高のリストを配列で取得します。
+func (c *BalanceListCaller) DoContext(ctx context.Context) ([]*BalanceResponse, bool, error) {
+	var hasMore bool
+	result, err := c.service.call(ctx, "balance.list", "", func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.queryList(ctx, "/balances", c.limit, c.offset, c.since, c.until, func(values *url.Values) bool {
+			result := false
+			if c.owner != "" {
+				values.Add("owner", c.owner)
+				result = true
+			}
+			if c.state != noBalanceState {
+				values.Add("state", c.state.status().(string))
+				result = true
+			}
+			if c.closed != nil {
+				values.Add("closed", strconv.FormatBool(*c.closed))
+				result = true
+			}
+			if c.tenantID != "" {
+				values.Add("tenant", c.tenantID)
+				result = true
+			}
+			return result
+		})
+		if err != nil {
+			return nil, err
+		}
+		raw := &listResponseParser{}
+		err = json.Unmarshal(body, raw)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]*BalanceResponse, len(raw.Data))
+		for i, rawBalance := range raw.Data {
+			balance := &BalanceResponse{}
+			json.Unmarshal(rawBalance, balance)
+			balance.setService(c.service)
+			result[i] = balance
+		}
+		hasMore = raw.HasMore
+		return result, nil
+	})
+	list, _ := result.([]*BalanceResponse)
+	return list, hasMore, err
+}
+
+// Iter は残高のリストを順に取得するイテレータを返します。次のページは自動的に取得されます。
+func (c *BalanceListCaller) Iter() *BalanceIter {
+	return c.IterContext(context.Background())
+}
+
+// IterContext はcontext.Contextを指定してイテレータを返します。ctxがキャンセルされると取得を中断します。
+func (c *BalanceListCaller) IterContext(ctx context.Context) *BalanceIter {
+	caller := *c
+	return &BalanceIter{newListIter(ctx, c.offset, c.limit, func(ctx context.Context, offset, limit int) ([]interface{}, bool, error) {
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	})}
+}
+
+// BalanceIter は残高のリストを順に取得するイテレータです。
+type BalanceIter struct {
+	listIter
+}
+
+// Max は取得する要素数の上限を設定します。0の場合は上限なしです。
+func (it *BalanceIter) Max(n int) *BalanceIter {
+	it.max = n
+	return it
+}
+
+// Value は現在の要素を返します。
+func (it *BalanceIter) Value() *BalanceResponse {
+	value, _ := it.current.(*BalanceResponse)
+	return value
+}
+
+// BankInfo は残高の入金先または請求元の銀行口座情報です
+type BankInfo struct {
+	BankCode              string // 銀行コード
+	BankBranchCode        string // 支店コード
+	BankAccountType       string // 預金種別
+	BankAccountNumber     string // 口座番号
+	BankAccountHolderName string // 口座名義
+	BankAccountStatus     string // 口座の状態
+}
+
+// BalanceResponse はBalanceService.Retrieve、BalanceService.Listで返される残高を表す構造体です
+type BalanceResponse struct {
+	ID         string               // ba_で始まる一意なオブジェクトを示す文字列
+	LiveMode   bool                 // 本番環境かどうか
+	CreatedAt  time.Time            // この残高作成時のタイムスタンプ
+	Net        int                  // 残高の合計金額
+	State      BalanceState         // 残高の状態
+	Closed     bool                 // 精算済みかどうか
+	DueDate    string               // 入金予定日または請求期限日(yyyy-mm-dd)
+	TenantID   string               // テナントID(テナントの残高のみ)
+	BankInfo   *BankInfo            // 入金先または請求元の銀行口座情報
+	Statements []*StatementResponse // この残高に含まれる取引明細
+
+	service *Service
+}
+
+type bankInfoParser struct {
+	BankAccountHolderName string `json:"bank_account_holder_name"`
+	BankAccountNumber     string `json:"bank_account_number"`
+	BankAccountStatus     string `json:"bank_account_status"`
+	BankAccountType       string `json:"bank_account_type"`
+	BankBranchCode        string `json:"bank_branch_code"`
+	BankCode              string `json:"bank_code"`
+}
+
+type balanceResponseParser struct {
+	BankInfo     *bankInfoParser   `json:"bank_info"`
+	Closed       bool              `json:"closed"`
+	CreatedEpoch int               `json:"created"`
+	DueDate      string            `json:"due_date"`
+	ID           string            `json:"id"`
+	LiveMode     bool              `json:"livemode"`
+	Net          int               `json:"net"`
+	Object       string            `json:"object"`
+	State        string            `json:"state"`
+	Statements   []json.RawMessage `json:"statements"`
+	TenantID     string            `json:"tenant_id"`
+}
+
+func (b *BalanceResponse) setService(service *Service) {
+	b.service = service
+	for _, statement := range b.Statements {
+		statement.setService(service)
+	}
+}
+
+// UnmarshalJSON はJSONパース用の内部APIです。
+func (b *BalanceResponse) UnmarshalJSON(data []byte) error {
+	raw := balanceResponseParser{}
+	err := json.Unmarshal(data, &raw)
+	if err == nil && raw.Object == "balance" {
+		b.BankInfo = nil
+		if raw.BankInfo != nil {
+			b.BankInfo = &BankInfo{
+				BankCode:              raw.BankInfo.BankCode,
+				BankBranchCode:        raw.BankInfo.BankBranchCode,
+				BankAccountType:       raw.BankInfo.BankAccountType,
+				BankAccountNumber:     raw.BankInfo.BankAccountNumber,
+				BankAccountHolderName: raw.BankInfo.BankAccountHolderName,
+				BankAccountStatus:     raw.BankInfo.BankAccountStatus,
+			}
+		}
+		b.Closed = raw.Closed
+		b.CreatedAt = time.Unix(int64(raw.CreatedEpoch), 0)
+		b.DueDate = raw.DueDate
+		b.ID = raw.ID
+		b.LiveMode = raw.LiveMode
+		b.Net = raw.Net
+		b.State = parseBalanceState(raw.State)
+		b.Statements = make([]*StatementResponse, len(raw.Statements))
+		for i, rawStatement := range raw.Statements {
+			statement := &StatementResponse{}
+			json.Unmarshal(rawStatement, statement)
+			b.Statements[i] = statement
+		}
+		b.TenantID = raw.TenantID
+		return nil
+	}
+	rawError := errorResponse{}
+	err = json.Unmarshal(data, &rawError)
+	if err == nil && rawError.Error.Status != 0 {
+		return &rawError.Error
+	}
+
+	return nil
+}
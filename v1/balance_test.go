@@ -0,0 +1,109 @@
+package payjp
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var balanceResponseJSON = []byte(`
+{
+  "bank_info": {
+    "bank_account_holder_name": "ﾍﾟｲ ｼﾞｪｲﾋﾟｰ",
+    "bank_account_number": "1234567",
+    "bank_account_status": "pending",
+    "bank_account_type": "普通",
+    "bank_branch_code": "123",
+    "bank_code": "0001"
+  },
+  "closed": false,
+  "created": 1695892351,
+  "due_date": "2023-10-31",
+  "id": "ba_sample_balance",
+  "livemode": true,
+  "net": -975,
+  "object": "balance",
+  "state": "claim",
+  "statements": [` + string(statementResponseJSON) + `],
+  "tenant_id": null
+}
+`)
+
+var balanceListResponseJSON = []byte(`
+{
+  "count": 1,
+  "data": [` + string(balanceResponseJSON) + `],
+  "has_more": false,
+  "object": "list",
+  "url": "/v1/balances"
+}
+`)
+
+func TestParseBalanceResponseJSON(t *testing.T) {
+	balance := &BalanceResponse{}
+	err := json.Unmarshal(balanceResponseJSON, balance)
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+	if balance.ID != "ba_sample_balance" || balance.Net != -975 || balance.State != BalanceClaim || balance.Closed || balance.DueDate != "2023-10-31" {
+		t.Errorf("parse error: %#v", balance)
+	}
+	if balance.BankInfo == nil || balance.BankInfo.BankCode != "0001" || balance.BankInfo.BankAccountStatus != "pending" {
+		t.Errorf("parse error: balance.BankInfo %#v", balance.BankInfo)
+	}
+	if len(balance.Statements) != 1 || balance.Statements[0].ID != "st_178fd25dc7ab7b75906f5d4c4b104" || balance.Statements[0].Term.ID != "tm_b92b879e60f62b532d6756ae12af" {
+		t.Errorf("parse error: balance.Statements %v", balance.Statements)
+	}
+}
+
+func TestBalanceRetrieve(t *testing.T) {
+	mock, transport := NewMockClient(200, balanceResponseJSON)
+	transport.AddResponse(200, statementListResponseJSON)
+	service := New("api-key", mock)
+	balance, err := service.Balance.Retrieve("ba_sample_balance")
+	if transport.URL != "https://api.pay.jp/v1/balances/ba_sample_balance" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if transport.Method != "GET" {
+		t.Errorf("Method should be GET, but %s", transport.Method)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	// 残高に含まれる取引明細から、同じ集計区間の取引明細をたどれる
+	statements, _, err := balance.Statements[0].Term.Statements().Do()
+	if err != nil || len(statements) != 1 {
+		t.Errorf("statements should be listed, but %v, %v", statements, err)
+	}
+	if transport.URL != "https://api.pay.jp/v1/statements?term=tm_b92b879e60f62b532d6756ae12af" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+}
+
+func TestBalanceList(t *testing.T) {
+	mock, transport := NewMockClient(200, balanceListResponseJSON)
+	service := New("api-key", mock)
+	balances, hasMore, err := service.Balance.List().
+		Limit(10).
+		Offset(15).
+		Since(time.Unix(1455328095, 0)).
+		Until(time.Unix(1455500895, 0)).
+		Owner("merchant").
+		State(BalanceClaim).
+		Closed(false).
+		TenantID("ten_121673955bd7aa144de5a8f6c262").Do()
+	if transport.URL != "https://api.pay.jp/v1/balances?closed=false&limit=10&offset=15&owner=merchant&since=1455328095&state=claim&tenant=ten_121673955bd7aa144de5a8f6c262&until=1455500895" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	if hasMore {
+		t.Error("parse error: hasMore")
+	}
+	if len(balances) != 1 || balances[0].ID != "ba_sample_balance" {
+		t.Errorf("parse error: balances %v", balances)
+	}
+}
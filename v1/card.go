@@ -1,30 +1,66 @@
 package payjp
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"time"
+
+	"github.com/payjp/payjp-go/v1/cardvalidate"
 )
 
 // Card はCustomerやTokenのAPIでカード情報を設定する時に使う構造体です
 type Card struct {
-	Name         interface{}       // カード保有者名(e.g. YUI ARAGAKI)
-	Number       interface{}       // カード番号
-	ExpMonth     interface{}       // 有効期限月
-	ExpYear      interface{}       // 有効期限年
-	CVC          interface{}       // CVCコード
-	Country      interface{}       // 2桁のISOコード(e.g. JP)
-	AddressZip   interface{}       // 郵便番号
-	AddressState interface{}       // 都道府県
-	AddressCity  interface{}       // 市区町村
-	AddressLine1 interface{}       // 番地など
-	AddressLine2 interface{}       // 建物名など
-	Metadata     map[string]string // メタデータ
+	Name         *string  `form:"name"`          // カード保有者名(e.g. YUI ARAGAKI)
+	Number       *string  `form:"number"`        // カード番号
+	ExpMonth     *int     `form:"exp_month"`     // 有効期限月
+	ExpYear      *int     `form:"exp_year"`      // 有効期限年
+	CVC          *string  `form:"cvc"`           // CVCコード
+	Country      *string  `form:"country"`       // 2桁のISOコード(e.g. JP)
+	AddressZip   *string  `form:"address_zip"`   // 郵便番号
+	AddressState *string  `form:"address_state"` // 都道府県
+	AddressCity  *string  `form:"address_city"`  // 市区町村
+	AddressLine1 *string  `form:"address_line1"` // 番地など
+	AddressLine2 *string  `form:"address_line2"` // 建物名など
+	Metadata     Metadata `form:"-"`             // メタデータ(トークンの作成時と、顧客のカードの作成・更新時のみ)
+}
+
+// cardParams はカードをcard[...]の形式で送信するためのパラメータです。カードのメタデータはトップレベルに置きます。
+type cardParams struct {
+	Card     Card     `form:"card"`
+	Metadata Metadata `form:"metadata"`
+}
+
+func newCardParams(card Card) cardParams {
+	return cardParams{Card: card, Metadata: card.Metadata}
+}
+
+// validate はカード情報をcardvalidateパッケージで検証します。
+// エラーがある場合は、最初のエラーをPAY.JPのカードエラーと同じ形式の*Errorで返します(Statusは0です)。
+// すべてのエラーはerrors.Asでcardvalidate.Errorsとして取得できます。
+func (c Card) validate() error {
+	err := cardvalidate.Validate(cardvalidate.Card{
+		Number:   c.Number,
+		ExpMonth: c.ExpMonth,
+		ExpYear:  c.ExpYear,
+		CVC:      c.CVC,
+		Country:  c.Country,
+	})
+	var errs cardvalidate.Errors
+	if !errors.As(err, &errs) {
+		return err
+	}
+	return &Error{
+		Code:    errs[0].Code,
+		Message: errs[0].Message,
+		Param:   errs[0].Param,
+		Type:    "card_error",
+		err:     errs,
+	}
 }
 
 func (c Card) valid() bool {
-	_, ok := c.Number.(string)
-	return ok
+	return c.Number != nil
 }
 
 func parseCard(service *Service, body []byte, result *CardResponse, customerID string) (*CardResponse, error) {
@@ -57,6 +93,8 @@ type CardResponse struct {
 	AddressLine2    string            // 建物名など
 	Metadata        map[string]string // メタデータ
 
+	ThreeDSecureStatus ThreeDSecureStatus // 3Dセキュアの認証状態
+
 	customerID string
 	service    *Service
 }
@@ -80,25 +118,43 @@ type cardResponseParser struct {
 	Name            string            `json:"name"`
 	Object          string            `json:"object"`
 	Metadata        map[string]string `json:"metadata"`
+
+	ThreeDSecureStatus string `json:"three_d_secure_status"`
 }
 
 // Update メソッドはカードの内容を更新します
 // Customer情報から得られるカードでしか更新はできません
 func (c *CardResponse) Update(card Card) error {
-	if c.customerID == "" {
+	return c.UpdateWithContext(context.Background(), card)
+}
+
+// UpdateWithContext メソッドはcontext.Contextを指定してカードの内容を更新します
+func (c *CardResponse) UpdateWithContext(ctx context.Context, card Card) error {
+	// トークンや支払いのカードはサービスを持たないため、呼び出しの前に判定する
+	if c.customerID == "" || c.service == nil {
 		return errors.New("Token's card doens't support Update()")
 	}
-	_, err := c.service.Customer.postCard(c.customerID, "/"+c.ID, card, c)
+	_, err := c.service.call(ctx, "card.update", c.ID, func(ctx context.Context) (interface{}, error) {
+		return c.service.Customer.postCard(ctx, c.customerID, "/"+c.ID, card, c)
+	})
 	return err
 }
 
 // Delete メソッドは顧客に登録されているカードを削除します
 // Customer情報から得られるカードでしか削除はできません
 func (c *CardResponse) Delete() error {
-	if c.customerID == "" {
+	return c.DeleteWithContext(context.Background())
+}
+
+// DeleteWithContext メソッドはcontext.Contextを指定して顧客に登録されているカードを削除します
+func (c *CardResponse) DeleteWithContext(ctx context.Context) error {
+	if c.customerID == "" || c.service == nil {
 		return errors.New("Token's card doens't support Delete()")
 	}
-	return c.service.delete("/customers/" + c.customerID + "/cards/" + c.ID)
+	_, err := c.service.call(ctx, "card.delete", c.ID, func(ctx context.Context) (interface{}, error) {
+		return nil, c.service.delete(ctx, "/customers/"+c.customerID+"/cards/"+c.ID)
+	})
+	return err
 }
 
 // UnmarshalJSON はJSONパース用の内部APIです。
@@ -123,6 +179,7 @@ func (c *CardResponse) UnmarshalJSON(b []byte) error {
 		c.Last4 = raw.Last4
 		c.Name = raw.Name
 		c.Metadata = raw.Metadata
+		c.ThreeDSecureStatus = parseThreeDSecureStatus(raw.ThreeDSecureStatus)
 		return nil
 	}
 	rawError := errorResponse{}
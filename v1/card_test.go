@@ -97,9 +97,9 @@ func TestCustomerAddCard(t *testing.T) {
 	transport.AddResponse(200, cardResponseJSON)
 	service := New("api-key", mock)
 	card, err := service.Customer.AddCard("cus_121673955bd7aa144de5a8f6c262", Card{
-		Number:   "4242424242424242",
-		ExpMonth: 2,
-		ExpYear:  2020,
+		Number:   String("4242424242424242"),
+		ExpMonth: Int(2),
+		ExpYear:  Int(2020),
 	})
 	if transport.URL != "https://api.pay.jp/v1/customers/cus_121673955bd7aa144de5a8f6c262/cards" {
 		t.Errorf("URL is wrong: %s", transport.URL)
@@ -128,10 +128,10 @@ func TestCustomerAddCard2(t *testing.T) {
 		return
 	}
 	card, err := customer.AddCard(Card{
-		Number:   "4242424242424242",
-		ExpMonth: "2",
-		ExpYear:  "2020",
-		CVC:      "000",
+		Number:   String("4242424242424242"),
+		ExpMonth: Int(2),
+		ExpYear:  Int(2020),
+		CVC:      String("000"),
 	})
 	if transport.URL != "https://api.pay.jp/v1/customers/cus_121673955bd7aa144de5a8f6c262/cards" {
 		t.Errorf("URL is wrong: %s", transport.URL)
@@ -200,9 +200,9 @@ func TestCustomerUpdateCard(t *testing.T) {
 	mock, transport := NewMockClient(200, cardResponseJSON)
 	service := New("api-key", mock)
 	card, err := service.Customer.UpdateCard("cus_121673955bd7aa144de5a8f6c262", "car_f7d9fa98594dc7c2e42bfcd641ff", Card{
-		Number:   "4242424242424242",
-		ExpMonth: 2,
-		ExpYear:  2020,
+		Number:   String("4242424242424242"),
+		ExpMonth: Int(2),
+		ExpYear:  Int(2020),
 	})
 	if transport.URL != "https://api.pay.jp/v1/customers/cus_121673955bd7aa144de5a8f6c262/cards/car_f7d9fa98594dc7c2e42bfcd641ff" {
 		t.Errorf("URL is wrong: %s", transport.URL)
@@ -231,10 +231,10 @@ func TestCustomerUpdateCard2(t *testing.T) {
 		return
 	}
 	err = customer.Cards[0].Update(Card{
-		Number:   "4242424242424242",
-		ExpMonth: "2",
-		ExpYear:  "2020",
-		CVC:      "000",
+		Number:   String("4242424242424242"),
+		ExpMonth: Int(2),
+		ExpYear:  Int(2020),
+		CVC:      String("000"),
 	})
 	if err != nil {
 		t.Errorf("err should be nil, but %v", err)
@@ -251,9 +251,9 @@ func TestCustomerUpdateCardError(t *testing.T) {
 	mock, _ := NewMockClient(200, cardErrorResponseJSON)
 	service := New("api-key", mock)
 	card, err := service.Customer.UpdateCard("cus_121673955bd7aa144de5a8f6c262", "car_f7d9fa98594dc7c2e42bfcd641ff", Card{
-		Number:   "4242424242424242",
-		ExpMonth: 2,
-		ExpYear:  2020,
+		Number:   String("4242424242424242"),
+		ExpMonth: Int(2),
+		ExpYear:  Int(2020),
 	})
 	if err == nil {
 		t.Error("err should not be nil")
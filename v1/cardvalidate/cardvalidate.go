@@ -0,0 +1,257 @@
+// Package cardvalidate はPAY.JPにカード情報を送信する前に、クライアント側で検証を行います。
+//
+// カード番号のLuhnチェック、ブランドの判定と桁数・CVCの桁数のチェック、有効期限切れ、
+// ISO 3166-1の国コードを検証し、PAY.JPのエラーと同じParam名とCodeを持つFieldErrorを返します:
+//
+//     err := cardvalidate.Validate(cardvalidate.Card{
+//         Number:   &number,
+//         ExpMonth: &month,
+//         ExpYear:  &year,
+//     })
+//     var errs cardvalidate.Errors
+//     if errors.As(err, &errs) {
+//         for _, e := range errs {
+//             fmt.Println(e.Param, e.Code) // card[number] invalid_number
+//         }
+//     }
+//
+// payjp.TokenService.Createは、このパッケージで検証してからトークンを生成します。
+package cardvalidate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Brand はカードブランドを表す文字列です。値はPAY.JPのカードオブジェクトのbrandと同じです。
+type Brand string
+
+const (
+	// Unknown は判定できなかったブランドを表す定数
+	Unknown Brand = ""
+	// Visa はVisaを表す定数
+	Visa Brand = "Visa"
+	// MasterCard はMastercardを表す定数
+	MasterCard Brand = "MasterCard"
+	// JCB はJCBを表す定数
+	JCB Brand = "JCB"
+	// AmericanExpress はAmerican Expressを表す定数
+	AmericanExpress Brand = "American Express"
+	// DinersClub はDiners Clubを表す定数
+	DinersClub Brand = "Diners Club"
+	// Discover はDiscoverを表す定数
+	Discover Brand = "Discover"
+)
+
+type brandRule struct {
+	brand   Brand
+	ranges  [][2]int // 先頭の桁の範囲(両端を含む)
+	lengths []int
+	cvc     int
+}
+
+var brandRules = []brandRule{
+	{Visa, [][2]int{{4, 4}}, []int{13, 16, 19}, 3},
+	{MasterCard, [][2]int{{51, 55}, {2221, 2720}}, []int{16}, 3},
+	{JCB, [][2]int{{3528, 3589}}, []int{16, 17, 18, 19}, 3},
+	{AmericanExpress, [][2]int{{34, 34}, {37, 37}}, []int{15}, 4},
+	{DinersClub, [][2]int{{300, 305}, {3095, 3095}, {36, 36}, {38, 39}}, []int{14, 15, 16, 17, 18, 19}, 3},
+	{Discover, [][2]int{{6011, 6011}, {622126, 622925}, {644, 649}, {65, 65}}, []int{16, 17, 18, 19}, 3},
+}
+
+func digitsOnly(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func prefixValue(number string, digits int) int {
+	value := 0
+	for i := 0; i < digits; i++ {
+		value = value*10 + int(number[i]-'0')
+	}
+	return value
+}
+
+func findRule(number string) *brandRule {
+	if !digitsOnly(number) {
+		return nil
+	}
+	for i := range brandRules {
+		rule := &brandRules[i]
+		for _, r := range rule.ranges {
+			digits := len(fmt.Sprint(r[0]))
+			if len(number) < digits {
+				continue
+			}
+			if prefix := prefixValue(number, digits); r[0] <= prefix && prefix <= r[1] {
+				return rule
+			}
+		}
+	}
+	return nil
+}
+
+// DetectBrand はカード番号の先頭の桁からブランドを判定します。判定できない場合はUnknownを返します。
+func DetectBrand(number string) Brand {
+	if rule := findRule(number); rule != nil {
+		return rule.brand
+	}
+	return Unknown
+}
+
+// CVCLength はブランドのCVCの桁数を返します。Unknownの場合は0を返します。
+func CVCLength(brand Brand) int {
+	for _, rule := range brandRules {
+		if rule.brand == brand {
+			return rule.cvc
+		}
+	}
+	return 0
+}
+
+// Luhn はカード番号がLuhnアルゴリズムのチェックディジットを満たしている場合にtrueを返します。
+func Luhn(number string) bool {
+	if !digitsOnly(number) {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		d := int(number[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// Card は検証するカード情報です。nilのフィールドは検証しません。
+type Card struct {
+	Number   *string // カード番号
+	ExpMonth *int    // 有効期限月
+	ExpYear  *int    // 有効期限年(4桁)
+	CVC      *string // CVCコード
+	Country  *string // 2桁のISOコード(e.g. JP)
+}
+
+// FieldError はカード情報のフィールドごとのエラーです。
+type FieldError struct {
+	Param   string // PAY.JPのエラーのparamと同じパラメータ名(e.g. card[number])
+	Code    string // PAY.JPのエラーのcodeと同じエラーコード(e.g. invalid_number)
+	Message string // エラーの内容
+}
+
+func (e *FieldError) Error() string {
+	return e.Param + ": " + e.Code + ": " + e.Message
+}
+
+// Errors はValidateが返すエラーで、検出したすべてのFieldErrorを含みます。
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return "cardvalidate: " + strings.Join(messages, ", ")
+}
+
+// Validate は現在時刻を基準にカード情報を検証します。エラーがある場合はErrorsを返します。
+func Validate(card Card) error {
+	return ValidateAt(card, time.Now())
+}
+
+// ValidateAt はnowを基準にカード情報を検証します。有効期限は、有効期限月の末日まで有効として扱います。
+func ValidateAt(card Card, now time.Time) error {
+	var errs Errors
+	add := func(param, code, format string, args ...interface{}) {
+		errs = append(errs, &FieldError{Param: param, Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	var rule *brandRule
+	if card.Number != nil {
+		number := *card.Number
+		rule = findRule(number)
+		switch {
+		case !digitsOnly(number):
+			add("card[number]", "invalid_number", "card number should consist of digits only")
+		case rule == nil:
+			add("card[number]", "unacceptable_brand", "card brand is not supported")
+		case !containsInt(rule.lengths, len(number)):
+			add("card[number]", "invalid_number", "%s card number should be %s digits, but %d", rule.brand, joinInts(rule.lengths), len(number))
+		case !Luhn(number):
+			add("card[number]", "invalid_number", "card number is invalid")
+		}
+	}
+
+	monthValid := false
+	if card.ExpMonth != nil {
+		if *card.ExpMonth < 1 || *card.ExpMonth > 12 {
+			add("card[exp_month]", "invalid_expiry_month", "expiry month should be between 1 and 12, but %d", *card.ExpMonth)
+		} else {
+			monthValid = true
+		}
+	}
+	yearValid := false
+	if card.ExpYear != nil {
+		if *card.ExpYear < 1000 || *card.ExpYear > 9999 {
+			add("card[exp_year]", "invalid_expiry_year", "expiry year should be 4 digits, but %d", *card.ExpYear)
+		} else {
+			yearValid = true
+		}
+	}
+	if monthValid && yearValid {
+		year, month := *card.ExpYear, *card.ExpMonth
+		if year < now.Year() || (year == now.Year() && month < int(now.Month())) {
+			add("card[exp_year]", "expired_card", "card has expired (%02d/%d)", month, year)
+		}
+	}
+
+	if card.CVC != nil {
+		cvc := *card.CVC
+		switch {
+		case !digitsOnly(cvc):
+			add("card[cvc]", "invalid_cvc", "CVC should consist of digits only")
+		case rule != nil && len(cvc) != rule.cvc:
+			add("card[cvc]", "invalid_cvc", "%s CVC should be %d digits, but %d", rule.brand, rule.cvc, len(cvc))
+		case rule == nil && (len(cvc) < 3 || len(cvc) > 4):
+			add("card[cvc]", "invalid_cvc", "CVC should be 3 or 4 digits, but %d", len(cvc))
+		}
+	}
+
+	if card.Country != nil && !ValidCountry(*card.Country) {
+		add("card[country]", "invalid_param", "country should be an ISO 3166-1 alpha-2 code, but %q", *card.Country)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func containsInt(list []int, v int) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+func joinInts(list []int) string {
+	s := make([]string, len(list))
+	for i, v := range list {
+		s[i] = fmt.Sprint(v)
+	}
+	return strings.Join(s, "/")
+}
@@ -0,0 +1,108 @@
+package cardvalidate
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDetectBrand(t *testing.T) {
+	cases := []struct {
+		number string
+		brand  Brand
+	}{
+		{"4242424242424242", Visa},
+		{"5555555555554444", MasterCard},
+		{"2223003122003222", MasterCard},
+		{"3530111333300000", JCB},
+		{"378282246310005", AmericanExpress},
+		{"30569309025904", DinersClub},
+		{"6011111111111117", Discover},
+		{"6221260000000000", Discover},
+		{"9999999999999999", Unknown},
+		{"4242-4242", Unknown},
+	}
+	for _, c := range cases {
+		if brand := DetectBrand(c.number); brand != c.brand {
+			t.Errorf("%s: brand should be %q, but %q", c.number, c.brand, brand)
+		}
+	}
+}
+
+func TestLuhn(t *testing.T) {
+	if !Luhn("4242424242424242") {
+		t.Error("4242424242424242 should pass Luhn check")
+	}
+	if Luhn("4242424242424241") {
+		t.Error("4242424242424241 should not pass Luhn check")
+	}
+	if Luhn("") {
+		t.Error("empty number should not pass Luhn check")
+	}
+}
+
+func TestValidCountry(t *testing.T) {
+	if !ValidCountry("JP") || !ValidCountry("US") {
+		t.Error("JP and US should be valid")
+	}
+	if ValidCountry("XX") || ValidCountry("jp") || ValidCountry("JPN") {
+		t.Error("XX, jp and JPN should be invalid")
+	}
+}
+
+func stringPtr(v string) *string { return &v }
+func intPtr(v int) *int          { return &v }
+
+func TestValidateAt(t *testing.T) {
+	now := time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name  string
+		card  Card
+		param string
+		code  string
+	}{
+		{"valid", Card{Number: stringPtr("4242424242424242"), ExpMonth: intPtr(5), ExpYear: intPtr(2024), CVC: stringPtr("123"), Country: stringPtr("JP")}, "", ""},
+		{"partial", Card{Country: stringPtr("JP")}, "", ""},
+		{"luhn", Card{Number: stringPtr("4242424242424241")}, "card[number]", "invalid_number"},
+		{"length", Card{Number: stringPtr("37828224631000")}, "card[number]", "invalid_number"},
+		{"brand", Card{Number: stringPtr("9999999999999995")}, "card[number]", "unacceptable_brand"},
+		{"digits", Card{Number: stringPtr("4242 4242 4242 4242")}, "card[number]", "invalid_number"},
+		{"month", Card{ExpMonth: intPtr(13), ExpYear: intPtr(2030)}, "card[exp_month]", "invalid_expiry_month"},
+		{"year", Card{ExpMonth: intPtr(1), ExpYear: intPtr(30)}, "card[exp_year]", "invalid_expiry_year"},
+		{"expired", Card{ExpMonth: intPtr(4), ExpYear: intPtr(2024)}, "card[exp_year]", "expired_card"},
+		{"amex cvc", Card{Number: stringPtr("378282246310005"), CVC: stringPtr("123")}, "card[cvc]", "invalid_cvc"},
+		{"cvc", Card{CVC: stringPtr("12")}, "card[cvc]", "invalid_cvc"},
+		{"country", Card{Country: stringPtr("XX")}, "card[country]", "invalid_param"},
+	}
+	for _, c := range cases {
+		err := ValidateAt(c.card, now)
+		if c.code == "" {
+			if err != nil {
+				t.Errorf("%s: err should be nil, but %v", c.name, err)
+			}
+			continue
+		}
+		var errs Errors
+		if !errors.As(err, &errs) {
+			t.Errorf("%s: err should be Errors, but %v", c.name, err)
+			continue
+		}
+		if len(errs) != 1 || errs[0].Param != c.param || errs[0].Code != c.code {
+			t.Errorf("%s: err should be %s %s, but %v", c.name, c.param, c.code, err)
+		}
+	}
+}
+
+func TestValidateMultipleErrors(t *testing.T) {
+	err := Validate(Card{Number: stringPtr("4242424242424241"), ExpMonth: intPtr(0), CVC: stringPtr("abc")})
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 3 {
+		t.Fatalf("err should have 3 errors, but %v", err)
+	}
+	params := []string{"card[number]", "card[exp_month]", "card[cvc]"}
+	for i, param := range params {
+		if errs[i].Param != param {
+			t.Errorf("errs[%d].Param should be %s, but %s", i, param, errs[i].Param)
+		}
+	}
+}
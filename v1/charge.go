@@ -1,9 +1,9 @@
 package payjp
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"net/url"
 	"strings"
 	"time"
@@ -14,6 +14,17 @@ type ChargeService struct {
 	service *Service
 }
 
+// 認証の期間として指定できる日数の範囲
+const (
+	minExpiryDays = 1
+	maxExpiryDays = 60
+)
+
+// validExpiryDays はCharge.ExpireDaysやReauthのexpiryDaysが指定可能な範囲にあるかを返します。
+func validExpiryDays(days int) bool {
+	return days >= minExpiryDays && days <= maxExpiryDays
+}
+
 func newChargeService(service *Service) *ChargeService {
 	return &ChargeService{
 		service: service,
@@ -22,15 +33,18 @@ func newChargeService(service *Service) *ChargeService {
 
 // Charge 構造体はCharge.Createのパラメータを設定するのに使用します
 type Charge struct {
-	Currency    string            // 必須: 3文字のISOコード(現状 “jpy” のみサポート)
-	CustomerID  string            // 顧客ID (CardかCustomerのどちらかは必須パラメータ)
-	Card        Card              // カードオブジェクト(cardかcustomerのどちらかは必須)
-	CardToken   string            // トークンID (CardかCustomerのどちらかは必須パラメータ)
-	CustomerCardID	string        // 顧客のカードID
-	Capture     bool              // 支払い処理を確定するかどうか (falseの場合、カードの認証と支払い額の確保のみ行う)
-	Description string            // 	概要
-	ExpireDays  interface{}       // デフォルトで7日となっており、1日~60日の間で設定が可能
-	Metadata    map[string]string // メタデータ
+	Currency       string   `form:"currency"`                 // 必須: 3文字のISOコード(現状 “jpy” のみサポート)
+	CustomerID     string   `form:"customer,omitempty"`       // 顧客ID (CardかCustomerのどちらかは必須パラメータ)
+	Card           Card     `form:"card"`                     // カードオブジェクト(cardかcustomerのどちらかは必須)
+	CardToken      string   `form:"card,omitempty"`           // トークンID (CardかCustomerのどちらかは必須パラメータ)
+	CustomerCardID string   `form:"card,omitempty"`           // 顧客のカードID(CustomerIDを指定した場合のみ)
+	Capture        bool     `form:"capture"`                  // 支払い処理を確定するかどうか (falseの場合、カードの認証と支払い額の確保のみ行う)
+	Description    string   `form:"description"`              // 	概要
+	ExpireDays     *int     `form:"expiry_days"`              // デフォルトで7日となっており、1日~60日の間で設定が可能
+	Metadata       Metadata `form:"metadata"`                 // メタデータ
+	TenantID       string   `form:"tenant,omitempty"`         // テナントID(PAY.JP Platformのみ)
+	PlatformFee    *int     `form:"platform_fee"`             // プラットフォーム利用料(PAY.JP Platformのみ)。省略時はテナントの利用料率から計算されます
+	ThreeDSecure   bool     `form:"three_d_secure,omitempty"` // 3Dセキュア認証を行うかどうか。trueの場合、支払いは認証が完了するまで保留されます
 }
 
 // Create はトークンID、カードを保有している顧客ID、カードオブジェクトのいずれかのパラメーターを指定して支払いを作成します。
@@ -39,151 +53,175 @@ type Charge struct {
 //
 // 支払いを確定せずに、カードの認証と支払い額のみ確保する場合は、 Capture に false を指定してください。 このとき ExpireDays を指定することで、認証の期間を定めることができます。 ExpireDays はデフォルトで7日となっており、1日~60日の間で設定が可能です。
 func (c ChargeService) Create(amount int, charge Charge) (*ChargeResponse, error) {
-	var errorMessages []string
-	if amount < 50 || amount > 9999999 {
-		errorMessages = append(errorMessages, fmt.Sprintf("Amount should be between 50 and 9,999,999, but %d.", amount))
-	}
-	counter := 0
-	if charge.CustomerID != "" {
-		counter++
-	}
-	if charge.CardToken != "" {
-		counter++
-	}
-	if charge.Card.valid() {
-		counter++
-	}
-	switch counter {
-	case 0:
-		errorMessages = append(errorMessages, "One of the following parameters is required: CustomerID, CardToken, Card")
-	case 1:
-	case 2, 3:
-		errorMessages = append(errorMessages, "The following parameters are exclusive: CustomerID, CardToken, Card")
-	}
-	if charge.Currency == "" {
-		charge.Currency = "jpy"
-	} else if charge.Currency != "jpy" {
-		// todo: if pay.jp supports other currency, fix this condition
-		errorMessages = append(errorMessages, fmt.Sprintf("Only supports 'jpy' as currency, but '%s'.", charge.Currency))
-	}
-	expireDays, ok := charge.ExpireDays.(int)
-	if ok && (expireDays < -1 || expireDays > 60) {
-		errorMessages = append(errorMessages, fmt.Sprintf("ExpireDays should be between 1 and 60, but %d.", expireDays))
-	}
-	if len(errorMessages) > 0 {
-		return nil, fmt.Errorf("Charge.Create() parameter error: %s", strings.Join(errorMessages, ", "))
-	}
-	qb := newRequestBuilder()
-	qb.Add("amount", amount)
-	qb.Add("currency", charge.Currency)
-	if charge.CustomerID != "" {
-		qb.Add("customer", charge.CustomerID)
-		if charge.CustomerCardID != "" {
-			qb.Add("card", charge.CustomerCardID)
-		}
-	} else if charge.CardToken != "" {
-		qb.Add("card", charge.CardToken)
-	}
-	qb.AddCard(charge.Card)
-	qb.Add("description", charge.Description)
-	qb.Add("capture", charge.Capture)
-	qb.Add("expiry_days", charge.ExpireDays)
-	qb.AddMetadata(charge.Metadata)
-
-	request, err := http.NewRequest("POST", c.service.apiBase+"/charges", qb.Reader())
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	request.Header.Add("Authorization", c.service.apiKey)
+	return c.CreateWithContext(context.Background(), amount, charge)
+}
 
-	body, err := respToBody(c.service.Client.Do(request))
-	if err != nil {
-		return nil, err
-	}
-	return parseCharge(c.service, body, &ChargeResponse{})
+// CreateWithContext はcontext.Contextを指定して支払いを作成します。
+//
+// WithIdempotencyKeyでキーを設定したctxを渡すと、同じ支払いが二重に作成されるのを防げます。
+func (c ChargeService) CreateWithContext(ctx context.Context, amount int, charge Charge) (*ChargeResponse, error) {
+	result, err := c.service.call(ctx, "charge.create", "", func(ctx context.Context) (interface{}, error) {
+		var errorMessages []string
+		if amount < 50 || amount > 9999999 {
+			errorMessages = append(errorMessages, fmt.Sprintf("Amount should be between 50 and 9,999,999, but %d.", amount))
+		}
+		counter := 0
+		if charge.CustomerID != "" {
+			counter++
+		}
+		if charge.CardToken != "" {
+			counter++
+		}
+		if charge.Card.valid() {
+			counter++
+		}
+		switch counter {
+		case 0:
+			errorMessages = append(errorMessages, "One of the following parameters is required: CustomerID, CardToken, Card")
+		case 1:
+		case 2, 3:
+			errorMessages = append(errorMessages, "The following parameters are exclusive: CustomerID, CardToken, Card")
+		}
+		if charge.Currency == "" {
+			charge.Currency = "jpy"
+		} else if charge.Currency != "jpy" {
+			// todo: if pay.jp supports other currency, fix this condition
+			errorMessages = append(errorMessages, fmt.Sprintf("Only supports 'jpy' as currency, but '%s'.", charge.Currency))
+		}
+		if charge.CustomerCardID != "" && charge.CustomerID == "" {
+			errorMessages = append(errorMessages, "CustomerCardID requires CustomerID.")
+		}
+		if charge.PlatformFee != nil && charge.TenantID == "" {
+			errorMessages = append(errorMessages, "PlatformFee requires TenantID.")
+		}
+		if charge.ExpireDays != nil && !validExpiryDays(*charge.ExpireDays) {
+			errorMessages = append(errorMessages, fmt.Sprintf("ExpireDays should be between %d and %d, but %d.", minExpiryDays, maxExpiryDays, *charge.ExpireDays))
+		}
+		if len(errorMessages) > 0 {
+			return nil, fmt.Errorf("Charge.Create() parameter error: %s", strings.Join(errorMessages, ", "))
+		}
+		values, err := encodeForm(struct {
+			Amount int `form:"amount"`
+			Charge
+		}{amount, charge})
+		if err != nil {
+			return nil, err
+		}
 
+		body, err := respToBody(c.service.request(ctx, "POST", "/charges", formBody(values)))
+		if err != nil {
+			return nil, err
+		}
+		return parseCharge(c.service, body, &ChargeResponse{})
+	})
+	response, _ := result.(*ChargeResponse)
+	return response, err
 }
 
 // Retrieve charge object. 支払い情報を取得します。
 func (c ChargeService) Retrieve(chargeID string) (*ChargeResponse, error) {
-	body, err := c.service.retrieve("/charges/" + chargeID)
-	if err != nil {
-		return nil, err
-	}
-	return parseCharge(c.service, body, &ChargeResponse{})
+	return c.RetrieveWithContext(context.Background(), chargeID)
+}
+
+// RetrieveWithContext はcontext.Contextを指定して支払い情報を取得します。
+func (c ChargeService) RetrieveWithContext(ctx context.Context, chargeID string) (*ChargeResponse, error) {
+	result, err := c.service.call(ctx, "charge.retrieve", chargeID, func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.retrieve(ctx, "/charges/"+chargeID)
+		if err != nil {
+			return nil, err
+		}
+		return parseCharge(c.service, body, &ChargeResponse{})
+	})
+	response, _ := result.(*ChargeResponse)
+	return response, err
 }
 
-func (c ChargeService) update(chargeID, description string, metadata map[string]string) ([]byte, error) {
-	qb := newRequestBuilder()
-	qb.Add("description", description)
-	qb.AddMetadata(metadata)
-	request, err := http.NewRequest("POST", c.service.apiBase+"/charges/"+chargeID, qb.Reader())
+func (c ChargeService) update(ctx context.Context, chargeID, description string, metadata Metadata) ([]byte, error) {
+	values, err := encodeForm(struct {
+		Description string   `form:"description"`
+		Metadata    Metadata `form:"metadata"`
+	}{description, metadata})
 	if err != nil {
 		return nil, err
 	}
-	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	request.Header.Add("Authorization", c.service.apiKey)
 
-	return parseResponseError(c.service.Client.Do(request))
+	return parseResponseError(c.service.request(ctx, "POST", "/charges/"+chargeID, formBody(values)))
 }
 
 // Update は支払い情報のDescriptionを更新します。
-func (c ChargeService) Update(chargeID, description string, metadata ...map[string]string) (*ChargeResponse, error) {
-	var md map[string]string
-	switch len(metadata) {
-	case 0:
-	case 1:
-		md = metadata[0]
-	default:
-		return nil, fmt.Errorf("Update can accept zero or one metadata map, but %d are passed", len(metadata))
-	}
-	body, err := c.update(chargeID, description, md)
-	if err != nil {
-		return nil, err
-	}
-	return parseCharge(c.service, body, &ChargeResponse{})
+func (c ChargeService) Update(chargeID, description string, metadata ...Metadata) (*ChargeResponse, error) {
+	return c.UpdateWithContext(context.Background(), chargeID, description, metadata...)
 }
 
-func (c ChargeService) refund(id string, reason string, amount []int) ([]byte, error) {
-	qb := newRequestBuilder()
+// UpdateWithContext はcontext.Contextを指定して支払い情報のDescriptionを更新します。
+func (c ChargeService) UpdateWithContext(ctx context.Context, chargeID, description string, metadata ...Metadata) (*ChargeResponse, error) {
+	result, err := c.service.call(ctx, "charge.update", chargeID, func(ctx context.Context) (interface{}, error) {
+		var md Metadata
+		switch len(metadata) {
+		case 0:
+		case 1:
+			md = metadata[0]
+		default:
+			return nil, fmt.Errorf("Update can accept zero or one metadata map, but %d are passed", len(metadata))
+		}
+		body, err := c.update(ctx, chargeID, description, md)
+		if err != nil {
+			return nil, err
+		}
+		return parseCharge(c.service, body, &ChargeResponse{})
+	})
+	response, _ := result.(*ChargeResponse)
+	return response, err
+}
+
+func (c ChargeService) refund(ctx context.Context, id string, reason string, amount []int) ([]byte, error) {
+	params := struct {
+		Amount       *int   `form:"amount"`
+		RefundReason string `form:"refund_reason"`
+	}{RefundReason: reason}
 	if len(amount) > 0 {
-		qb.Add("amount", amount[0])
+		params.Amount = &amount[0]
 	}
-	qb.Add("refund_reason", reason)
-	request, err := http.NewRequest("POST", c.service.apiBase+"/charges/"+id+"/refund", qb.Reader())
+	values, err := encodeForm(params)
 	if err != nil {
 		return nil, err
 	}
-	request.Header.Add("Authorization", c.service.apiKey)
 
-	return parseResponseError(c.service.Client.Do(request))
+	return parseResponseError(c.service.request(ctx, "POST", "/charges/"+id+"/refund", formBody(values)))
 }
 
 // Refund は支払い済みとなった処理を返金します。
 // Amount省略時は全額返金、指定時に金額の部分返金を行うことができます。
 func (c ChargeService) Refund(chargeID, reason string, amount ...int) (*ChargeResponse, error) {
-	body, err := c.refund(chargeID, reason, amount)
-	if err != nil {
-		return nil, err
-	}
-	return parseCharge(c.service, body, &ChargeResponse{})
+	return c.RefundWithContext(context.Background(), chargeID, reason, amount...)
 }
 
+// RefundWithContext はcontext.Contextを指定して支払い済みとなった処理を返金します。
+func (c ChargeService) RefundWithContext(ctx context.Context, chargeID, reason string, amount ...int) (*ChargeResponse, error) {
+	result, err := c.service.call(ctx, "charge.refund", chargeID, func(ctx context.Context) (interface{}, error) {
+		body, err := c.refund(ctx, chargeID, reason, amount)
+		if err != nil {
+			return nil, err
+		}
+		return parseCharge(c.service, body, &ChargeResponse{})
+	})
+	response, _ := result.(*ChargeResponse)
+	return response, err
+}
 
-
-func (c ChargeService) capture(chargeID string, amount []int) ([]byte, error) {
-	qb := newRequestBuilder()
+func (c ChargeService) capture(ctx context.Context, chargeID string, amount []int) ([]byte, error) {
+	var params struct {
+		Amount *int `form:"amount"`
+	}
 	if len(amount) > 0 {
-		qb.Add("amount", amount[0])
+		params.Amount = &amount[0]
 	}
-	request, err := http.NewRequest("POST", c.service.apiBase+"/charges/"+chargeID+"/capture", qb.Reader())
+	values, err := encodeForm(params)
 	if err != nil {
 		return nil, err
 	}
-	request.Header.Add("Authorization", c.service.apiKey)
 
-	return parseResponseError(c.service.Client.Do(request))
+	return parseResponseError(c.service.request(ctx, "POST", "/charges/"+chargeID+"/capture", formBody(values)))
 }
 
 // Capture は認証状態となった処理待ちの支払い処理を確定させます。具体的には Captured="false" となった支払いが該当します。
@@ -194,11 +232,84 @@ func (c ChargeService) capture(chargeID string, amount []int) ([]byte, error) {
 //
 // 例えば、認証時に amount=500 で作成し、 amount=400 で支払い確定を行った場合、 AmountRefunded=100 となり、確定金額が400円に変更された状態で支払いが確定されます。
 func (c ChargeService) Capture(chargeID string, amount ...int) (*ChargeResponse, error) {
-	body, err := c.capture(chargeID, amount)
+	return c.CaptureWithContext(context.Background(), chargeID, amount...)
+}
+
+// CaptureWithContext はcontext.Contextを指定して処理待ちの支払い処理を確定させます。
+func (c ChargeService) CaptureWithContext(ctx context.Context, chargeID string, amount ...int) (*ChargeResponse, error) {
+	result, err := c.service.call(ctx, "charge.capture", chargeID, func(ctx context.Context) (interface{}, error) {
+		body, err := c.capture(ctx, chargeID, amount)
+		if err != nil {
+			return nil, err
+		}
+		return parseCharge(c.service, body, &ChargeResponse{})
+	})
+	response, _ := result.(*ChargeResponse)
+	return response, err
+}
+
+func (c ChargeService) reauth(ctx context.Context, chargeID string, expiryDays []int) ([]byte, error) {
+	var params struct {
+		ExpiryDays *int `form:"expiry_days"`
+	}
+	if len(expiryDays) > 0 {
+		if !validExpiryDays(expiryDays[0]) {
+			return nil, fmt.Errorf("Charge.Reauth() parameter error: ExpiryDays should be between %d and %d, but %d.", minExpiryDays, maxExpiryDays, expiryDays[0])
+		}
+		params.ExpiryDays = &expiryDays[0]
+	}
+	values, err := encodeForm(params)
 	if err != nil {
 		return nil, err
 	}
-	return parseCharge(c.service, body, &ChargeResponse{})
+
+	return parseResponseError(c.service.request(ctx, "POST", "/charges/"+chargeID+"/reauth", formBody(values)))
+}
+
+// Reauth は認証状態の支払いを再認証し、認証の期限を延長します。具体的には Captured=false の支払いが該当します。
+//
+// expiryDays を省略した場合は7日となり、1日~60日の間で設定が可能です。
+// 再認証に成功すると ExpiredAt が更新されます。すでに返金や期限切れとなった支払いは再認証できません。
+func (c ChargeService) Reauth(chargeID string, expiryDays ...int) (*ChargeResponse, error) {
+	return c.ReauthWithContext(context.Background(), chargeID, expiryDays...)
+}
+
+// ReauthWithContext はcontext.Contextを指定して認証状態の支払いを再認証します。
+func (c ChargeService) ReauthWithContext(ctx context.Context, chargeID string, expiryDays ...int) (*ChargeResponse, error) {
+	result, err := c.service.call(ctx, "charge.reauth", chargeID, func(ctx context.Context) (interface{}, error) {
+		body, err := c.reauth(ctx, chargeID, expiryDays)
+		if err != nil {
+			return nil, err
+		}
+		return parseCharge(c.service, body, &ChargeResponse{})
+	})
+	response, _ := result.(*ChargeResponse)
+	return response, err
+}
+
+func (c ChargeService) tdsFinish(ctx context.Context, chargeID string) ([]byte, error) {
+	return parseResponseError(c.service.request(ctx, "POST", "/charges/"+chargeID+"/tds_finish", nil))
+}
+
+// TdsFinish は3Dセキュア認証が終了した支払いの処理を完了させます。
+//
+// ThreeDSecure を指定して作成した支払いは、顧客が認証を終えた後にこのメソッドを呼ぶことで支払い処理が行われます。
+// 認証の結果は ThreeDSecureStatus で確認できます。
+func (c ChargeService) TdsFinish(chargeID string) (*ChargeResponse, error) {
+	return c.TdsFinishWithContext(context.Background(), chargeID)
+}
+
+// TdsFinishWithContext はcontext.Contextを指定して3Dセキュア認証が終了した支払いの処理を完了させます。
+func (c ChargeService) TdsFinishWithContext(ctx context.Context, chargeID string) (*ChargeResponse, error) {
+	result, err := c.service.call(ctx, "charge.tds_finish", chargeID, func(ctx context.Context) (interface{}, error) {
+		body, err := c.tdsFinish(ctx, chargeID)
+		if err != nil {
+			return nil, err
+		}
+		return parseCharge(c.service, body, &ChargeResponse{})
+	})
+	response, _ := result.(*ChargeResponse)
+	return response, err
 }
 
 // List は生成した支払い情報のリストを取得します。リストは、直近で生成された順番に取得されます。
@@ -222,6 +333,7 @@ type ChargeListCaller struct {
 	until          int
 	customerID     string
 	subscriptionID string
+	tenantID       string
 }
 
 // Limit はリストの要素数の最大値を設定します(1-100)
@@ -260,36 +372,132 @@ func (c *ChargeListCaller) SubscriptionID(id string) *ChargeListCaller {
 	return c
 }
 
+// TenantID を指定すると、指定したテナントの支払いのみを取得します(PAY.JP Platformのみ)
+func (c *ChargeListCaller) TenantID(id string) *ChargeListCaller {
+	c.tenantID = id
+	return c
+}
+
 // Do は指定されたクエリーを元に支払いのリストを配列で取得します。
 func (c *ChargeListCaller) Do() ([]*ChargeResponse, bool, error) {
-	body, err := c.service.queryList("/charges", c.limit, c.offset, c.since, c.until, func(values *url.Values) bool {
-		result := false
-		if c.customerID != "" {
-			values.Add("customer", c.customerID)
-			result = true
+	return c.DoContext(context.Background())
+}
+
+// DoContext はcontext.Contextを指定して支払いのリストを配列で取得します。
+func (c *ChargeListCaller) DoContext(ctx context.Context) ([]*ChargeResponse, bool, error) {
+	var hasMore bool
+	result, err := c.service.call(ctx, "charge.list", "", func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.queryList(ctx, "/charges", c.limit, c.offset, c.since, c.until, func(values *url.Values) bool {
+			result := false
+			if c.customerID != "" {
+				values.Add("customer", c.customerID)
+				result = true
+			}
+			if c.subscriptionID != "" {
+				values.Add("subscription", c.subscriptionID)
+				result = true
+			}
+			if c.tenantID != "" {
+				values.Add("tenant", c.tenantID)
+				result = true
+			}
+			return result
+		})
+		if err != nil {
+			return nil, err
+		}
+		raw := &listResponseParser{}
+		err = json.Unmarshal(body, raw)
+		if err != nil {
+			return nil, err
 		}
-		if c.subscriptionID != "" {
-			values.Add("subscription", c.subscriptionID)
-			result = true
+		result := make([]*ChargeResponse, len(raw.Data))
+		for i, rawCharge := range raw.Data {
+			charge := &ChargeResponse{}
+			json.Unmarshal(rawCharge, charge)
+			charge.service = c.service
+			result[i] = charge
 		}
-		return result
+		hasMore = raw.HasMore
+		return result, nil
 	})
-	if err != nil {
-		return nil, false, err
-	}
-	raw := &listResponseParser{}
-	err = json.Unmarshal(body, raw)
-	if err != nil {
-		return nil, false, err
-	}
-	result := make([]*ChargeResponse, len(raw.Data))
-	for i, rawCharge := range raw.Data {
-		charge := &ChargeResponse{}
-		json.Unmarshal(rawCharge, charge)
-		charge.service = c.service
-		result[i] = charge
-	}
-	return result, raw.HasMore, nil
+	list, _ := result.([]*ChargeResponse)
+	return list, hasMore, err
+}
+
+// Iter は支払いのリストを順に取得するイテレータを返します。
+// 次のページは必要になった時点で自動的に取得されます:
+//
+//     iter := pay.Charge.List().Since(since).Iter()
+//     for iter.Next() {
+//         charge := iter.Value()
+//         ...
+//     }
+//     if err := iter.Err(); err != nil {
+//         ...
+//     }
+func (c *ChargeListCaller) Iter() *ChargeIter {
+	return c.IterContext(context.Background())
+}
+
+// IterContext はcontext.Contextを指定してイテレータを返します。ctxがキャンセルされると取得を中断します。
+func (c *ChargeListCaller) IterContext(ctx context.Context) *ChargeIter {
+	caller := *c
+	return &ChargeIter{newListIter(ctx, c.offset, c.limit, func(ctx context.Context, offset, limit int) ([]interface{}, bool, error) {
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	})}
+}
+
+// WindowIter は作成日時の時間窓をずらしながら支払いのリストを順に取得するイテレータを返します。
+//
+// Iter()はOffsetでページを送るため、取得中に新しい支払いが作成されると要素が重複したり欠落したりします。
+// WindowIter()はUntilを過去へ移動しながら取得し、重複をIDで取り除くため、
+// Since/Untilで指定した期間の支払いを過不足なく取得できます。Offsetの指定は無視されます。
+func (c *ChargeListCaller) WindowIter() *ChargeIter {
+	return c.WindowIterContext(context.Background())
+}
+
+// WindowIterContext はcontext.Contextを指定してWindowIter()と同様のイテレータを返します。
+func (c *ChargeListCaller) WindowIterContext(ctx context.Context) *ChargeIter {
+	caller := *c
+	return &ChargeIter{newListIter(ctx, 0, c.limit, windowPages(c.until, func(ctx context.Context, until, offset, limit int) ([]interface{}, bool, error) {
+		caller.until = until
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	}, func(item interface{}) (string, int) {
+		value := item.(*ChargeResponse)
+		return value.ID, int(value.CreatedAt.Unix())
+	}))}
+}
+
+// ChargeIter は支払いのリストを順に取得するイテレータです。
+type ChargeIter struct {
+	listIter
+}
+
+// Max は取得する要素数の上限を設定します。0の場合は上限なしです。
+func (it *ChargeIter) Max(n int) *ChargeIter {
+	it.max = n
+	return it
+}
+
+// Value は現在の要素を返します。
+func (it *ChargeIter) Value() *ChargeResponse {
+	value, _ := it.current.(*ChargeResponse)
+	return value
 }
 
 func parseCharge(service *Service, data []byte, result *ChargeResponse) (*ChargeResponse, error) {
@@ -303,58 +511,76 @@ func parseCharge(service *Service, data []byte, result *ChargeResponse) (*Charge
 
 // ChargeResponse はCharge.Getなどで返される、支払いに関する情報を持った構造体です
 type ChargeResponse struct {
-	ID             string            // ch_で始まる一意なオブジェクトを示す文字列
-	LiveMode       bool              // 本番環境かどうか
-	CreatedAt      time.Time         // この支払い作成時のタイムスタンプ
-	Amount         int               // 支払額
-	Currency       string            // 3文字のISOコード(現状 “jpy” のみサポート)
-	Paid           bool              // 認証処理が成功しているかどうか。
-	ExpiredAt      time.Time         // 認証状態が自動的に失効される日時のタイムスタンプ
-	Captured       bool              // 支払い処理を確定しているかどうか
-	CapturedAt     time.Time         // 支払い処理確定時のタイムスタンプ
-	Card           CardResponse      // 支払いされたクレジットカードの情報
-	CustomerID     string            // 顧客ID
-	Description    string            // 概要
-	FailureCode    string            // 失敗した支払いのエラーコード
-	FailureMessage string            // 失敗した支払いの説明
-	Refunded       bool              // 返金済みかどうか
-	AmountRefunded int               // この支払いに対しての返金額
-	RefundReason   string            // 返金理由
-	SubscriptionID string            // sub_から始まる定期課金のID
-	Metadata       map[string]string // メタデータ
-	FeeRate        string            // 決済手数料率
+	ID               string            // ch_で始まる一意なオブジェクトを示す文字列
+	LiveMode         bool              // 本番環境かどうか
+	CreatedAt        time.Time         // この支払い作成時のタイムスタンプ
+	Amount           int               // 支払額
+	Currency         string            // 3文字のISOコード(現状 “jpy” のみサポート)
+	Paid             bool              // 認証処理が成功しているかどうか。
+	ExpiredAt        time.Time         // 認証状態が自動的に失効される日時のタイムスタンプ
+	Captured         bool              // 支払い処理を確定しているかどうか
+	CapturedAt       time.Time         // 支払い処理確定時のタイムスタンプ
+	Card             CardResponse      // 支払いされたクレジットカードの情報
+	CustomerID       string            // 顧客ID
+	Description      string            // 概要
+	FailureCode      string            // 失敗した支払いのエラーコード
+	FailureMessage   string            // 失敗した支払いの説明
+	Refunded         bool              // 返金済みかどうか
+	AmountRefunded   int               // この支払いに対しての返金額
+	RefundReason     string            // 返金理由
+	SubscriptionID   string            // sub_から始まる定期課金のID
+	Metadata         map[string]string // メタデータ
+	FeeRate          string            // 決済手数料率
+	TenantID         string            // テナントID(PAY.JP Platformのみ)
+	PlatformFee      int               // プラットフォーム利用料(PAY.JP Platformのみ)
+	PlatformFeeRate  string            // プラットフォーム利用料率(PAY.JP Platformのみ)
+	TotalPlatformFee int               // 返金を反映したプラットフォーム利用料の総額(PAY.JP Platformのみ)
+
+	ThreeDSecureStatus ThreeDSecureStatus // 3Dセキュアの認証状態
 
 	service *Service
 }
 
 // Update は支払い情報のDescriptionとメタデータ(オプション)を更新します
-func (c *ChargeResponse) Update(description string, metadata ...map[string]string) error {
-	var md map[string]string
-	switch len(metadata) {
-	case 0:
-	case 1:
-		md = metadata[0]
-	default:
-		return fmt.Errorf("Update can accept zero or one metadata map, but %d are passed", len(metadata))
-	}
-	body, err := c.service.Charge.update(c.ID, description, md)
-	if err != nil {
-		return err
-	}
-	_, err = parseCharge(c.service, body, c)
+func (c *ChargeResponse) Update(description string, metadata ...Metadata) error {
+	return c.UpdateWithContext(context.Background(), description, metadata...)
+}
+
+// UpdateWithContext はcontext.Contextを指定して支払い情報のDescriptionとメタデータ(オプション)を更新します
+func (c *ChargeResponse) UpdateWithContext(ctx context.Context, description string, metadata ...Metadata) error {
+	_, err := c.service.call(ctx, "charge.update", c.ID, func(ctx context.Context) (interface{}, error) {
+		var md Metadata
+		switch len(metadata) {
+		case 0:
+		case 1:
+			md = metadata[0]
+		default:
+			return nil, fmt.Errorf("Update can accept zero or one metadata map, but %d are passed", len(metadata))
+		}
+		body, err := c.service.Charge.update(ctx, c.ID, description, md)
+		if err != nil {
+			return nil, err
+		}
+		return parseCharge(c.service, body, c)
+	})
 	return err
 }
 
 // Refund 支払い済みとなった処理を返金します。
 // 全額返金、及び amount を指定することで金額の部分返金を行うことができます。ただし部分返金を最初に行った場合、2度目の返金は全額返金しか行うことができないため、ご注意ください。
 func (c *ChargeResponse) Refund(reason string, amount ...int) error {
-	var body []byte
-	var err error
-	body, err = c.service.Charge.refund(c.ID, reason, amount)
-	if err != nil {
-		return err
-	}
-	_, err = parseCharge(c.service, body, c)
+	return c.RefundWithContext(context.Background(), reason, amount...)
+}
+
+// RefundWithContext はcontext.Contextを指定して支払い済みとなった処理を返金します。
+func (c *ChargeResponse) RefundWithContext(ctx context.Context, reason string, amount ...int) error {
+	_, err := c.service.call(ctx, "charge.refund", c.ID, func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.Charge.refund(ctx, c.ID, reason, amount)
+		if err != nil {
+			return nil, err
+		}
+		return parseCharge(c.service, body, c)
+	})
 	return err
 }
 
@@ -366,36 +592,85 @@ func (c *ChargeResponse) Refund(reason string, amount ...int) error {
 //
 // 例えば、認証時に amount=500 で作成し、 amount=400 で支払い確定を行った場合、 AmountRefunded=100 となり、確定金額が400円に変更された状態で支払いが確定されます。
 func (c *ChargeResponse) Capture(amount ...int) error {
-	body, err := c.service.Charge.capture(c.ID, amount)
-	if err != nil {
-		return err
-	}
-	_, err = parseCharge(c.service, body, c)
+	return c.CaptureWithContext(context.Background(), amount...)
+}
+
+// CaptureWithContext はcontext.Contextを指定して処理待ちの支払い処理を確定させます。
+func (c *ChargeResponse) CaptureWithContext(ctx context.Context, amount ...int) error {
+	_, err := c.service.call(ctx, "charge.capture", c.ID, func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.Charge.capture(ctx, c.ID, amount)
+		if err != nil {
+			return nil, err
+		}
+		return parseCharge(c.service, body, c)
+	})
+	return err
+}
+
+// Reauth は認証状態の支払いを再認証し、認証の期限を延長します。
+//
+// expiryDays を省略した場合は7日となり、1日~60日の間で設定が可能です。
+func (c *ChargeResponse) Reauth(expiryDays ...int) error {
+	return c.ReauthWithContext(context.Background(), expiryDays...)
+}
+
+// ReauthWithContext はcontext.Contextを指定して認証状態の支払いを再認証します。
+func (c *ChargeResponse) ReauthWithContext(ctx context.Context, expiryDays ...int) error {
+	_, err := c.service.call(ctx, "charge.reauth", c.ID, func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.Charge.reauth(ctx, c.ID, expiryDays)
+		if err != nil {
+			return nil, err
+		}
+		return parseCharge(c.service, body, c)
+	})
+	return err
+}
+
+// TdsFinish は3Dセキュア認証が終了した支払いの処理を完了させます。
+func (c *ChargeResponse) TdsFinish() error {
+	return c.TdsFinishWithContext(context.Background())
+}
+
+// TdsFinishWithContext はcontext.Contextを指定して3Dセキュア認証が終了した支払いの処理を完了させます。
+func (c *ChargeResponse) TdsFinishWithContext(ctx context.Context) error {
+	_, err := c.service.call(ctx, "charge.tds_finish", c.ID, func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.Charge.tdsFinish(ctx, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		return parseCharge(c.service, body, c)
+	})
 	return err
 }
 
 type chargeResponseParser struct {
-	Amount         int               `json:"amount"`
-	AmountRefunded int               `json:"amount_refunded"`
-	Captured       bool              `json:"captured"`
-	CapturedEpoch  int               `json:"captured_at"`
-	Card           json.RawMessage   `json:"card"`
-	CreatedEpoch   int               `json:"created"`
-	Currency       string            `json:"currency"`
-	Customer       string            `json:"customer"`
-	Description    string            `json:"description"`
-	ExpiredEpoch   int               `json:"expired_at"`
-	FailureCode    string            `json:"failure_code"`
-	FailureMessage string            `json:"failure_message"`
-	ID             string            `json:"id"`
-	LiveMode       bool              `json:"livemode"`
-	Object         string            `json:"object"`
-	Paid           bool              `json:"paid"`
-	RefundReason   string            `json:"refund_reason"`
-	Refunded       bool              `json:"refunded"`
-	Subscription   string            `json:"subscription"`
-	Metadata       map[string]string `json:"metadata"`
-	FeeRate        string            `json:"fee_rate"`
+	Amount           int               `json:"amount"`
+	AmountRefunded   int               `json:"amount_refunded"`
+	Captured         bool              `json:"captured"`
+	CapturedEpoch    int               `json:"captured_at"`
+	Card             json.RawMessage   `json:"card"`
+	CreatedEpoch     int               `json:"created"`
+	Currency         string            `json:"currency"`
+	Customer         string            `json:"customer"`
+	Description      string            `json:"description"`
+	ExpiredEpoch     int               `json:"expired_at"`
+	FailureCode      string            `json:"failure_code"`
+	FailureMessage   string            `json:"failure_message"`
+	ID               string            `json:"id"`
+	LiveMode         bool              `json:"livemode"`
+	Object           string            `json:"object"`
+	Paid             bool              `json:"paid"`
+	RefundReason     string            `json:"refund_reason"`
+	Refunded         bool              `json:"refunded"`
+	Subscription     string            `json:"subscription"`
+	Metadata         map[string]string `json:"metadata"`
+	FeeRate          string            `json:"fee_rate"`
+	Tenant           string            `json:"tenant"`
+	PlatformFee      int               `json:"platform_fee"`
+	PlatformFeeRate  string            `json:"platform_fee_rate"`
+	TotalPlatformFee int               `json:"total_platform_fee"`
+
+	ThreeDSecureStatus string `json:"three_d_secure_status"`
 }
 
 // UnmarshalJSON はJSONパース用の内部APIです。
@@ -423,6 +698,11 @@ func (c *ChargeResponse) UnmarshalJSON(b []byte) error {
 		c.SubscriptionID = raw.Subscription
 		c.Metadata = raw.Metadata
 		c.FeeRate = raw.FeeRate
+		c.TenantID = raw.Tenant
+		c.PlatformFee = raw.PlatformFee
+		c.PlatformFeeRate = raw.PlatformFeeRate
+		c.TotalPlatformFee = raw.TotalPlatformFee
+		c.ThreeDSecureStatus = parseThreeDSecureStatus(raw.ThreeDSecureStatus)
 		return nil
 	}
 	rawError := errorResponse{}
@@ -1,6 +1,7 @@
 package payjp
 
 import (
+	"bytes"
 	"encoding/json"
 	"testing"
 	"time"
@@ -112,6 +113,15 @@ var chargeErrorResponseJSON = []byte(`
 }
 `)
 
+var platformChargeResponseJSON = bytes.Replace(chargeResponseJSON, []byte(`"object": "charge",`), []byte(`"object": "charge",
+  "platform_fee": 100,
+  "platform_fee_rate": "10.15",
+  "tenant": "ten_121673955bd7aa144de5a8f6c262",
+  "total_platform_fee": 100,`), 1)
+
+var threeDSecureChargeResponseJSON = bytes.Replace(chargeResponseJSON, []byte(`"object": "charge",`), []byte(`"object": "charge",
+  "three_d_secure_status": "verified",`), 1)
+
 func TestParseChargeResponseJSON(t *testing.T) {
 	charge := &ChargeResponse{}
 	err := json.Unmarshal(chargeResponseJSON, charge)
@@ -135,9 +145,9 @@ func TestChargeCreate(t *testing.T) {
 	service := New("api-key", mock)
 	charge, err := service.Charge.Create(1000, Charge{
 		Card: Card{
-			Number:   "4242424242424242",
-			ExpMonth: 2,
-			ExpYear:  2020,
+			Number:   String("4242424242424242"),
+			ExpMonth: Int(2),
+			ExpYear:  Int(2020),
 		},
 	})
 	if transport.URL != "https://api.pay.jp/v1/charges" {
@@ -157,6 +167,32 @@ func TestChargeCreate(t *testing.T) {
 	}
 }
 
+func TestChargeCreateExpireDays(t *testing.T) {
+	mock, transport := NewMockClient(200, chargeResponseJSON)
+	service := New("api-key", mock)
+	for _, days := range []int{-1, 0, 61} {
+		_, err := service.Charge.Create(1000, Charge{
+			CardToken:  "tok_xxxxx",
+			ExpireDays: Int(days),
+		})
+		if err == nil {
+			t.Errorf("err should not be nil when ExpireDays is %d", days)
+		}
+	}
+	if transport.URL != "" {
+		t.Errorf("request should not be sent, but %s", transport.URL)
+	}
+	for _, days := range []int{1, 60} {
+		_, err := service.Charge.Create(1000, Charge{
+			CardToken:  "tok_xxxxx",
+			ExpireDays: Int(days),
+		})
+		if err != nil {
+			t.Errorf("err should be nil when ExpireDays is %d, but %v", days, err)
+		}
+	}
+}
+
 func TestChargeCreateByNonDefaultard(t *testing.T) {
 	mock, transport := NewMockClient(200, chargeResponseJSON)
 	service := New("api-key", mock)
@@ -179,6 +215,79 @@ func TestChargeCreateByNonDefaultard(t *testing.T) {
 	}
 }
 
+func TestChargeCreateWithTenant(t *testing.T) {
+	server, form := newFormRecordingServer(platformChargeResponseJSON)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+	charge, err := service.Charge.Create(1000, Charge{
+		CardToken:   "tok_xxxxx",
+		Capture:     true,
+		TenantID:    "ten_121673955bd7aa144de5a8f6c262",
+		PlatformFee: Int(100),
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if form.Get("tenant") != "ten_121673955bd7aa144de5a8f6c262" || form.Get("platform_fee") != "100" {
+		t.Errorf("form is wrong: %s", form.Encode())
+	}
+	if charge.TenantID != "ten_121673955bd7aa144de5a8f6c262" || charge.PlatformFee != 100 || charge.PlatformFeeRate != "10.15" || charge.TotalPlatformFee != 100 {
+		t.Errorf("parse error: %#v", charge)
+	}
+
+	_, err = service.Charge.Create(1000, Charge{CardToken: "tok_xxxxx", PlatformFee: Int(100)})
+	if err == nil {
+		t.Error("err should not be nil when PlatformFee is set without TenantID")
+	}
+}
+
+func TestChargeCreateWithThreeDSecure(t *testing.T) {
+	server, form := newFormRecordingServer(threeDSecureChargeResponseJSON)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+	_, err := service.Charge.Create(1000, Charge{
+		CardToken:    "tok_xxxxx",
+		ThreeDSecure: true,
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if form.Get("three_d_secure") != "true" {
+		t.Errorf("form is wrong: %s", form.Encode())
+	}
+
+	_, err = service.Charge.Create(1000, Charge{CardToken: "tok_xxxxx"})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if _, ok := (*form)["three_d_secure"]; ok {
+		t.Errorf("three_d_secure should not be sent by default: %s", form.Encode())
+	}
+}
+
+func TestChargeTdsFinish(t *testing.T) {
+	mock, transport := NewMockClient(200, threeDSecureChargeResponseJSON)
+	service := New("api-key", mock)
+	charge, err := service.Charge.TdsFinish("ch_fa990a4c10672a93053a774730b0a")
+	if transport.URL != "https://api.pay.jp/v1/charges/ch_fa990a4c10672a93053a774730b0a/tds_finish" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if transport.Method != "POST" {
+		t.Errorf("Method should be POST, but %s", transport.Method)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	if charge.ThreeDSecureStatus != ThreeDSecureVerified {
+		t.Errorf("charge.ThreeDSecureStatus should be ThreeDSecureVerified, but %v", charge.ThreeDSecureStatus)
+	}
+	err = charge.TdsFinish()
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+}
+
 func TestChargeRetrieve(t *testing.T) {
 	mock, transport := NewMockClient(200, chargeResponseJSON)
 	service := New("api-key", mock)
@@ -355,6 +464,33 @@ func TestChargeCaptureChangedAmount(t *testing.T) {
 	}
 }
 
+func TestChargeReauth(t *testing.T) {
+	server, form := newFormRecordingServer(chargeResponseJSON)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+	charge, err := service.Charge.Reauth("ch_fa990a4c10672a93053a774730b0a", 30)
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if form.Encode() != "expiry_days=30" {
+		t.Errorf("form is wrong: %s", form.Encode())
+	}
+	err = charge.Reauth()
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+	if form.Encode() != "" {
+		t.Errorf("form should be empty, but %s", form.Encode())
+	}
+
+	for _, days := range []int{-1, 0, 61} {
+		_, err = service.Charge.Reauth("ch_fa990a4c10672a93053a774730b0a", days)
+		if err == nil {
+			t.Errorf("err should not be nil when ExpiryDays is %d", days)
+		}
+	}
+}
+
 func TestChargeList(t *testing.T) {
 	mock, transport := NewMockClient(200, chargeListResponseJSON)
 	service := New("api-key", mock)
@@ -380,3 +516,21 @@ func TestChargeList(t *testing.T) {
 		t.Error("parse error: plans")
 	}
 }
+
+func TestChargeListTenant(t *testing.T) {
+	mock, transport := NewMockClient(200, chargeListResponseJSON)
+	service := New("api-key", mock)
+	charges, _, err := service.Charge.List().
+		Limit(10).
+		TenantID("ten_121673955bd7aa144de5a8f6c262").Do()
+	if transport.URL != "https://api.pay.jp/v1/charges?limit=10&tenant=ten_121673955bd7aa144de5a8f6c262" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	if len(charges) != 1 {
+		t.Error("parse error: charges")
+	}
+}
@@ -1,16 +1,34 @@
 package payjp
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 // Config 構造体はNewに渡すパラメータを設定するのに使用します。
 type Config struct {
 	APIBase string // APIのエンドポイントのURL(省略時は'https://api.pay.jp/v1')
+
+	MaxRetries        int           // 429、5xx、ネットワークエラー時に再試行する最大回数(省略時は再試行しない)
+	RetryInitialDelay time.Duration // 初回の再試行までの待ち時間(省略時は0.5秒)。以降は再試行ごとに倍になります
+	RetryMaxDelay     time.Duration // 再試行までの待ち時間の上限(省略時は30秒)。Retry-Afterヘッダーの値にも適用されます
+
+	AutoIdempotencyKey bool // trueの場合、すべてのPOSTリクエストにIdempotency-Keyを自動で付与します
+
+	Interceptors []Interceptor // すべてのAPI呼び出しに適用するInterceptor。Service.Useで後から追加することもできます
+	Tracer       Tracer        // API呼び出しごとにスパンを作成するTracer(省略時はトレースしない)
+	Metrics      Metrics       // API呼び出しごとの計測値の記録先(省略時は記録しない)
+
+	Logger             Logger   // リクエストごとのログの出力先(省略時はログを出力しない)
+	RedactMetadataKeys []string // ログで値を伏せるメタデータのキー。カード番号、CVC、APIキーは常に伏せられます
 }
 
 // Service 構造体はPAY.JPのすべてのAPIの起点となる構造体です。
@@ -19,6 +37,12 @@ type Service struct {
 	Client  *http.Client
 	apiKey  string
 	apiBase string
+	retry   retryPolicy
+
+	autoIdempotencyKey bool
+	interceptors       []Interceptor
+	logger             Logger
+	redactMetadataKeys map[string]bool
 
 	Charge       *ChargeService       // 支払いに関するAPI
 	Customer     *CustomerService     // 顧客情報に関するAPI
@@ -28,6 +52,13 @@ type Service struct {
 	Transfer     *TransferService     // 入金に関するAPI
 	Event        *EventService        // イベント情報に関するAPI
 	Account      *AccountService      // アカウント情報に関するAPI
+	Tenant       *TenantService       // PAY.JP Platformのテナントに関するAPI
+	Statement    *StatementService    // 取引明細に関するAPI
+	Balance      *BalanceService      // 残高に関するAPI
+	Term         *TermService         // 集計区間に関するAPI
+
+	TenantTransfer      *TenantTransferService      // PAY.JP Platformのテナントへの入金に関するAPI
+	ThreeDSecureRequest *ThreeDSecureRequestService // 顧客のカードに対する3Dセキュアリクエストに関するAPI
 }
 
 // New はPAY.JPのAPIを初期化する関数です。
@@ -36,7 +67,7 @@ type Service struct {
 //
 // clientは特別な設定をしたhttp.Clientを使用する場合に渡します。nilを指定するとデフォルトのもhttp.Clientを指定します。
 //
-// configは追加の設定が必要な場合に渡します。現状で設定できるのはAPIのエントリーポイントのURLのみです。省略できます。
+// configは追加の設定が必要な場合に渡します。APIのエントリーポイントのURLや再試行の設定ができます。省略できます。
 func New(apiKey string, client *http.Client, config ...Config) *Service {
 	if client == nil {
 		client = &http.Client{}
@@ -47,6 +78,21 @@ func New(apiKey string, client *http.Client, config ...Config) *Service {
 	}
 	if len(config) > 0 {
 		service.apiBase = config[0].APIBase
+		service.retry = newRetryPolicy(config[0])
+		service.autoIdempotencyKey = config[0].AutoIdempotencyKey
+		if config[0].Tracer != nil {
+			// スパンにすべてのInterceptorの処理時間を含めるため、最も外側に置く
+			service.interceptors = append(service.interceptors, tracingInterceptor(config[0].Tracer))
+		}
+		if config[0].Metrics != nil {
+			service.interceptors = append(service.interceptors, metricsInterceptor(config[0].Metrics))
+		}
+		service.interceptors = append(service.interceptors, config[0].Interceptors...)
+		service.logger = config[0].Logger
+		service.redactMetadataKeys = make(map[string]bool, len(config[0].RedactMetadataKeys))
+		for _, key := range config[0].RedactMetadataKeys {
+			service.redactMetadataKeys[key] = true
+		}
 	} else {
 		service.apiBase = "https://api.pay.jp/v1"
 	}
@@ -59,6 +105,12 @@ func New(apiKey string, client *http.Client, config ...Config) *Service {
 	service.Token = newTokenService(service)
 	service.Transfer = newTransferService(service)
 	service.Event = newEventService(service)
+	service.Tenant = newTenantService(service)
+	service.TenantTransfer = newTenantTransferService(service)
+	service.Statement = newStatementService(service)
+	service.Balance = newBalanceService(service)
+	service.Term = newTermService(service)
+	service.ThreeDSecureRequest = newThreeDSecureRequestService(service)
 
 	return service
 }
@@ -68,36 +120,100 @@ func (s Service) APIBase() string {
 	return s.apiBase
 }
 
-func (s Service) retrieve(resourceURL string) ([]byte, error) {
-	request, err := http.NewRequest("GET", s.apiBase+resourceURL, nil)
+// request はAPIキーなどの共通ヘッダーを付与してリクエストを送信します。
+// ctxがキャンセルされるかデッドラインを過ぎた場合、リクエストは中断されます。
+//
+// 再試行が設定されている場合、冪等なリクエストは失敗時に再送されます。
+// Idempotency-Keyを付与したリクエストは、再送時にも同じキーを使用します。
+func (s Service) request(ctx context.Context, method, resourcePath string, body io.Reader) (*http.Response, error) {
+	idempotencyKey, err := s.idempotencyKey(ctx, method)
 	if err != nil {
 		return nil, err
 	}
-	request.Header.Add("Authorization", s.apiKey)
-
-	return respToBody(s.Client.Do(request))
+	var payload []byte
+	if body != nil {
+		payload, err = ioutil.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	call := callFromContext(ctx)
+	for attempt := 0; ; attempt++ {
+		request, err := s.newRequest(ctx, method, resourcePath, payload, idempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		resp, err := s.Client.Do(request)
+		if err != nil && ctx.Err() == nil {
+			err = &NetworkError{Err: err}
+		}
+		if s.logger != nil {
+			s.logRequest(ctx, request, payload, resp, err, attempt, time.Since(start))
+		}
+		if call != nil {
+			call.record(method, resourcePath, resp)
+		}
+		if attempt >= s.retry.maxRetries || !s.retry.shouldRetry(request, resp, err) {
+			if meta := responseFromContext(ctx); meta != nil && resp != nil {
+				if err := recordResponse(meta, resp); err != nil {
+					return nil, err
+				}
+			}
+			return resp, err
+		}
+		wait := s.retry.delay(attempt, resp)
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
 }
 
-func (s Service) delete(resourceURL string) error {
-	request, err := http.NewRequest("DELETE", s.apiBase+resourceURL, nil)
+func (s Service) newRequest(ctx context.Context, method, resourcePath string, payload []byte, idempotencyKey string) (*http.Request, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, s.apiBase+resourcePath, body)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if body != nil {
+		request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	}
 	request.Header.Add("Authorization", s.apiKey)
+	if idempotencyKey != "" {
+		request.Header.Add("Idempotency-Key", idempotencyKey)
+	}
+	return request, nil
+}
+
+func (s Service) retrieve(ctx context.Context, resourceURL string) ([]byte, error) {
+	return respToBody(s.request(ctx, "GET", resourceURL, nil))
+}
 
-	_, err = parseResponseError(s.Client.Do(request))
+func (s Service) delete(ctx context.Context, resourceURL string) error {
+	_, err := parseResponseError(s.request(ctx, "DELETE", resourceURL, nil))
 	return err
 }
 
-func (s Service) queryList(resourcePath string, limit, offset, since, until int, callbacks ...func(*url.Values) bool) ([]byte, error) {
-	return s.queryListAll(resourcePath, limit, offset, since, until, 0, 0, callbacks...)
+func (s Service) queryList(ctx context.Context, resourcePath string, limit, offset, since, until int, callbacks ...func(*url.Values) bool) ([]byte, error) {
+	return s.queryListAll(ctx, resourcePath, limit, offset, since, until, 0, 0, callbacks...)
 }
 
-func (s Service) queryTransferList(resourcePath string, limit, offset, since, until, sinceSheduledDate, untilSheduledDate int, callbacks ...func(*url.Values) bool) ([]byte, error) {
-	return s.queryListAll(resourcePath, limit, offset, since, until, sinceSheduledDate, untilSheduledDate, callbacks...)
+func (s Service) queryTransferList(ctx context.Context, resourcePath string, limit, offset, since, until, sinceScheduledDate, untilScheduledDate int, callbacks ...func(*url.Values) bool) ([]byte, error) {
+	return s.queryListAll(ctx, resourcePath, limit, offset, since, until, sinceScheduledDate, untilScheduledDate, callbacks...)
 }
 
-func (s Service) queryListAll(resourcePath string, limit, offset, since, until, sinceSheduledDate, untilSheduledDate int, callbacks ...func(*url.Values) bool) ([]byte, error) {
+func (s Service) queryListAll(ctx context.Context, resourcePath string, limit, offset, since, until, sinceScheduledDate, untilScheduledDate int, callbacks ...func(*url.Values) bool) ([]byte, error) {
 	if limit < 0 || limit > 100 {
 		return nil, fmt.Errorf("method Limit() should be between 1 and 100, but %d", limit)
 	}
@@ -120,12 +236,12 @@ func (s Service) queryListAll(resourcePath string, limit, offset, since, until,
 		values.Add("until", strconv.Itoa(until))
 		hasParam = true
 	}
-	if sinceSheduledDate != 0 {
-		values.Add("since_sheduled_date", strconv.Itoa(sinceSheduledDate))
+	if sinceScheduledDate != 0 {
+		values.Add("since_scheduled_date", strconv.Itoa(sinceScheduledDate))
 		hasParam = true
 	}
-	if untilSheduledDate != 0 {
-		values.Add("until_sheduled_date", strconv.Itoa(untilSheduledDate))
+	if untilScheduledDate != 0 {
+		values.Add("until_scheduled_date", strconv.Itoa(untilScheduledDate))
 		hasParam = true
 	}
 	// add extra parameters
@@ -134,17 +250,9 @@ func (s Service) queryListAll(resourcePath string, limit, offset, since, until,
 			hasParam = true
 		}
 	}
-	var requestURL string
 	if hasParam {
-		requestURL = s.apiBase + resourcePath + "?" + values.Encode()
-	} else {
-		requestURL = s.apiBase + resourcePath
+		resourcePath = resourcePath + "?" + values.Encode()
 	}
-	request, err := http.NewRequest("GET", requestURL, nil)
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Add("Authorization", s.apiKey)
 
-	return respToBody(s.Client.Do(request))
+	return respToBody(s.request(ctx, "GET", resourcePath, nil))
 }
@@ -1,8 +1,12 @@
 package payjp
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestNewClient(t *testing.T) {
@@ -45,3 +49,83 @@ func TestNewClientWithConfig(t *testing.T) {
 		t.Errorf(`ApiBase should be "https://api.pay.jp/v2", but "%s"`, service.APIBase())
 	}
 }
+
+func newSlowServer(delay time.Duration) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		w.Write(chargeResponseJSON)
+	}))
+}
+
+func TestContextDeadline(t *testing.T) {
+	server := newSlowServer(time.Second)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	charge, err := service.Charge.RetrieveWithContext(ctx, "ch_fa990a4c10672a93053a774730b0a")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err should be context.DeadlineExceeded, but %v", err)
+	}
+	if charge != nil {
+		t.Errorf("charge should be nil, but %v", charge)
+	}
+}
+
+func TestContextCancel(t *testing.T) {
+	server := newSlowServer(time.Second)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, _, err := service.Charge.List().DoContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err should be context.Canceled, but %v", err)
+	}
+}
+
+func TestContextAlreadyCanceled(t *testing.T) {
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	}))
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := service.Charge.CreateWithContext(ctx, 1000, Charge{CardToken: "tok_xxxxx"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err should be context.Canceled, but %v", err)
+	}
+	if requested {
+		t.Error("request should not be sent")
+	}
+}
+
+func TestContextPassed(t *testing.T) {
+	server := newSlowServer(0)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	charge, err := service.Charge.RetrieveWithContext(ctx, "ch_fa990a4c10672a93053a774730b0a")
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	err = charge.RefundWithContext(ctx, "reason")
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+}
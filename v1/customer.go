@@ -1,8 +1,9 @@
 package payjp
 
 import (
+	"context"
 	"encoding/json"
-	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -22,13 +23,20 @@ func newCustomerService(service *Service) *CustomerService {
 
 // Customer は顧客の登録や更新時に使用する構造体です
 type Customer struct {
-	Email       interface{}       // メールアドレス
-	Description interface{}       // 概要
-	ID          interface{}       // 一意の顧客ID
-	CardToken   interface{}       // トークンID
-	DefaultCard interface{}       // デフォルトカード
-	Card        Card              // カード
-	Metadata    map[string]string // メタデータ
+	Email       *string  `form:"email"`                  // メールアドレス
+	Description *string  `form:"description"`            // 概要
+	ID          *string  `form:"id,omitempty"`           // 一意の顧客ID(作成時のみ設定可能)
+	CardToken   *string  `form:"card,omitempty"`         // トークンID
+	DefaultCard *string  `form:"default_card,omitempty"` // デフォルトカード(更新時のみ設定可能)
+	Card        Card     `form:"card"`                   // カード
+	Metadata    Metadata `form:"metadata"`               // メタデータ
+}
+
+func (c Customer) values() (url.Values, error) {
+	if c.CardToken != nil && *c.CardToken != "" {
+		c.Card = Card{}
+	}
+	return encodeForm(c)
 }
 
 func parseCustomer(service *Service, body []byte, result *CustomerResponse) (*CustomerResponse, error) {
@@ -52,87 +60,87 @@ func parseCustomer(service *Service, body []byte, result *CustomerResponse) (*Cu
 //
 // DefaultCardは更新時のみ設定が可能です
 func (c CustomerService) Create(customer Customer) (*CustomerResponse, error) {
-	qb := newRequestBuilder()
-	if customer.Email != "" {
-		qb.Add("email", customer.Email)
-	}
-	if customer.Description != "" {
-		qb.Add("description", customer.Description)
-	}
-	if customer.ID != "" {
-		qb.Add("id", customer.ID)
-	}
-	if customer.CardToken != "" {
-		qb.Add("card", customer.CardToken)
-	} else {
-		qb.AddCard(customer.Card)
-	}
-	qb.AddMetadata(customer.Metadata)
+	return c.CreateWithContext(context.Background(), customer)
+}
 
-	request, err := http.NewRequest("POST", c.service.apiBase+"/customers", qb.Reader())
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	request.Header.Add("Authorization", c.service.apiKey)
+// CreateWithContext はcontext.Contextを指定して顧客を作成します。
+func (c CustomerService) CreateWithContext(ctx context.Context, customer Customer) (*CustomerResponse, error) {
+	result, err := c.service.call(ctx, "customer.create", "", func(ctx context.Context) (interface{}, error) {
+		customer.DefaultCard = nil
+		values, err := customer.values()
+		if err != nil {
+			return nil, err
+		}
 
-	body, err := respToBody(c.service.Client.Do(request))
-	if err != nil {
-		return nil, err
-	}
-	return parseCustomer(c.service, body, &CustomerResponse{})
+		body, err := respToBody(c.service.request(ctx, "POST", "/customers", formBody(values)))
+		if err != nil {
+			return nil, err
+		}
+		return parseCustomer(c.service, body, &CustomerResponse{})
+	})
+	response, _ := result.(*CustomerResponse)
+	return response, err
 }
 
 // Retrieve customer object. 顧客情報を取得します。
 func (c CustomerService) Retrieve(id string) (*CustomerResponse, error) {
-	body, err := c.service.retrieve("/customers/" + id)
-	if err != nil {
-		return nil, err
-	}
-	return parseCustomer(c.service, body, &CustomerResponse{})
+	return c.RetrieveWithContext(context.Background(), id)
+}
+
+// RetrieveWithContext はcontext.Contextを指定して顧客情報を取得します。
+func (c CustomerService) RetrieveWithContext(ctx context.Context, id string) (*CustomerResponse, error) {
+	result, err := c.service.call(ctx, "customer.retrieve", id, func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.retrieve(ctx, "/customers/"+id)
+		if err != nil {
+			return nil, err
+		}
+		return parseCustomer(c.service, body, &CustomerResponse{})
+	})
+	response, _ := result.(*CustomerResponse)
+	return response, err
 }
 
 // Update は生成した顧客情報を更新したり、新たなカードを顧客に追加します。
 //
 // また default_card に保持しているカードIDを指定することで、メイン利用のカードを変更することもできます。
 func (c CustomerService) Update(id string, customer Customer) (*CustomerResponse, error) {
-	body, err := c.update(id, customer)
-	if err != nil {
-		return nil, err
-	}
-	return parseCustomer(c.service, body, &CustomerResponse{})
+	return c.UpdateWithContext(context.Background(), id, customer)
 }
 
-func (c CustomerService) update(id string, customer Customer) ([]byte, error) {
-	qb := newRequestBuilder()
-	if customer.Email != "" {
-		qb.Add("email", customer.Email)
-	}
-	if customer.Description != "" {
-		qb.Add("description", customer.Description)
-	}
-	if customer.DefaultCard != "" {
-		qb.Add("default_card", customer.DefaultCard)
-	}
-	if customer.CardToken != "" {
-		qb.Add("card", customer.CardToken)
-	} else {
-		qb.AddCard(customer.Card)
-	}
-	qb.AddMetadata(customer.Metadata)
-	request, err := http.NewRequest("POST", c.service.apiBase+"/customers/"+id, qb.Reader())
+// UpdateWithContext はcontext.Contextを指定して顧客情報を更新します。
+func (c CustomerService) UpdateWithContext(ctx context.Context, id string, customer Customer) (*CustomerResponse, error) {
+	result, err := c.service.call(ctx, "customer.update", id, func(ctx context.Context) (interface{}, error) {
+		body, err := c.update(ctx, id, customer)
+		if err != nil {
+			return nil, err
+		}
+		return parseCustomer(c.service, body, &CustomerResponse{})
+	})
+	response, _ := result.(*CustomerResponse)
+	return response, err
+}
+
+func (c CustomerService) update(ctx context.Context, id string, customer Customer) ([]byte, error) {
+	customer.ID = nil
+	values, err := customer.values()
 	if err != nil {
 		return nil, err
 	}
-	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	request.Header.Add("Authorization", c.service.apiKey)
 
-	return parseResponseError(c.service.Client.Do(request))
+	return parseResponseError(c.service.request(ctx, "POST", "/customers/"+id, formBody(values)))
 }
 
 // Delete は生成した顧客情報を削除します。削除した顧客情報は、もう一度生成することができないためご注意ください。
 func (c CustomerService) Delete(id string) error {
-	return c.service.delete("/customers/" + id)
+	return c.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext はcontext.Contextを指定して顧客情報を削除します。
+func (c CustomerService) DeleteWithContext(ctx context.Context, id string) error {
+	_, err := c.service.call(ctx, "customer.delete", id, func(ctx context.Context) (interface{}, error) {
+		return nil, c.service.delete(ctx, "/customers/"+id)
+	})
+	return err
 }
 
 // List は生成した顧客情報のリストを取得します。リストは、直近で生成された順番に取得されます。
@@ -144,35 +152,32 @@ func (c CustomerService) List() *CustomerListCaller {
 
 // AddCardToken はトークンIDを指定して、新たにカードを追加します。ただし同じカード番号および同じ有効期限年/月のカードは、重複追加することができません。
 func (c CustomerService) AddCardToken(customerID, token string) (*CardResponse, error) {
-	qb := newRequestBuilder()
-	qb.Add("card", token)
+	return c.AddCardTokenWithContext(context.Background(), customerID, token)
+}
 
-	request, err := http.NewRequest("POST", c.service.apiBase+"/customers/"+customerID+"/cards", qb.Reader())
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	request.Header.Add("Authorization", c.service.apiKey)
+// AddCardTokenWithContext はcontext.Contextを指定して、トークンIDからカードを追加します。
+func (c CustomerService) AddCardTokenWithContext(ctx context.Context, customerID, token string) (*CardResponse, error) {
+	result, err := c.service.call(ctx, "card.create", customerID, func(ctx context.Context) (interface{}, error) {
+		values := url.Values{}
+		values.Set("card", token)
 
-	body, err := respToBody(c.service.Client.Do(request))
-	if err != nil {
-		return nil, err
-	}
-	return parseCard(c.service, body, &CardResponse{}, customerID)
+		body, err := respToBody(c.service.request(ctx, "POST", "/customers/"+customerID+"/cards", formBody(values)))
+		if err != nil {
+			return nil, err
+		}
+		return parseCard(c.service, body, &CardResponse{}, customerID)
+	})
+	response, _ := result.(*CardResponse)
+	return response, err
 }
 
-func (c CustomerService) postCard(customerID, resourcePath string, card Card, result *CardResponse) (*CardResponse, error) {
-	qb := newRequestBuilder()
-	qb.AddCard(card)
-
-	request, err := http.NewRequest("POST", c.service.apiBase+"/customers/"+customerID+"/cards"+resourcePath, qb.Reader())
+func (c CustomerService) postCard(ctx context.Context, customerID, resourcePath string, card Card, result *CardResponse) (*CardResponse, error) {
+	values, err := encodeForm(newCardParams(card))
 	if err != nil {
 		return nil, err
 	}
-	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	request.Header.Add("Authorization", c.service.apiKey)
 
-	body, err := respToBody(c.service.Client.Do(request))
+	body, err := respToBody(c.service.request(ctx, "POST", "/customers/"+customerID+"/cards"+resourcePath, formBody(values)))
 	if err != nil {
 		return nil, err
 	}
@@ -181,30 +186,65 @@ func (c CustomerService) postCard(customerID, resourcePath string, card Card, re
 
 // AddCard はカード情報のパラメーターを指定して、新たにカードを追加します。ただし同じカード番号および同じ有効期限年/月のカードは、重複追加することができません。
 func (c CustomerService) AddCard(customerID string, card Card) (*CardResponse, error) {
-	return c.postCard(customerID, "", card, &CardResponse{})
+	return c.AddCardWithContext(context.Background(), customerID, card)
+}
+
+// AddCardWithContext はcontext.Contextを指定して、カード情報のパラメーターからカードを追加します。
+func (c CustomerService) AddCardWithContext(ctx context.Context, customerID string, card Card) (*CardResponse, error) {
+	result, err := c.service.call(ctx, "card.create", customerID, func(ctx context.Context) (interface{}, error) {
+		return c.postCard(ctx, customerID, "", card, &CardResponse{})
+	})
+	response, _ := result.(*CardResponse)
+	return response, err
 }
 
 // GetCard は顧客の特定のカード情報を取得します。
 func (c CustomerService) GetCard(customerID, cardID string) (*CardResponse, error) {
-	body, err := c.service.retrieve("/customers/" + customerID + "/cards/" + cardID)
-	if err != nil {
-		return nil, err
-	}
-	return parseCard(c.service, body, &CardResponse{}, customerID)
+	return c.GetCardWithContext(context.Background(), customerID, cardID)
+}
+
+// GetCardWithContext はcontext.Contextを指定して顧客の特定のカード情報を取得します。
+func (c CustomerService) GetCardWithContext(ctx context.Context, customerID, cardID string) (*CardResponse, error) {
+	result, err := c.service.call(ctx, "card.retrieve", cardID, func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.retrieve(ctx, "/customers/"+customerID+"/cards/"+cardID)
+		if err != nil {
+			return nil, err
+		}
+		return parseCard(c.service, body, &CardResponse{}, customerID)
+	})
+	response, _ := result.(*CardResponse)
+	return response, err
 }
 
 // UpdateCard は顧客の特定のカード情報を更新します。
 func (c CustomerService) UpdateCard(customerID, cardID string, card Card) (*CardResponse, error) {
-	result := &CardResponse{
-		customerID: customerID,
-		service:    c.service,
-	}
-	return c.postCard(customerID, "/"+cardID, card, result)
+	return c.UpdateCardWithContext(context.Background(), customerID, cardID, card)
+}
+
+// UpdateCardWithContext はcontext.Contextを指定して顧客の特定のカード情報を更新します。
+func (c CustomerService) UpdateCardWithContext(ctx context.Context, customerID, cardID string, card Card) (*CardResponse, error) {
+	result, err := c.service.call(ctx, "card.update", cardID, func(ctx context.Context) (interface{}, error) {
+		result := &CardResponse{
+			customerID: customerID,
+			service:    c.service,
+		}
+		return c.postCard(ctx, customerID, "/"+cardID, card, result)
+	})
+	response, _ := result.(*CardResponse)
+	return response, err
 }
 
 // DeleteCard は顧客の特定のカードを削除します。
 func (c CustomerService) DeleteCard(customerID, cardID string) error {
-	return c.service.delete("/customers/" + customerID + "/cards/" + cardID)
+	return c.DeleteCardWithContext(context.Background(), customerID, cardID)
+}
+
+// DeleteCardWithContext はcontext.Contextを指定して顧客の特定のカードを削除します。
+func (c CustomerService) DeleteCardWithContext(ctx context.Context, customerID, cardID string) error {
+	_, err := c.service.call(ctx, "card.delete", cardID, func(ctx context.Context) (interface{}, error) {
+		return nil, c.service.delete(ctx, "/customers/"+customerID+"/cards/"+cardID)
+	})
+	return err
 }
 
 // ListCard は顧客の保持しているカードリストを取得します。リストは、直近で生成された順番に取得されます。
@@ -217,7 +257,16 @@ func (c CustomerService) ListCard(customerID string) *CustomerCardListCaller {
 
 // GetSubscription は顧客の特定の定期課金情報を取得します。
 func (c CustomerService) GetSubscription(customerID, subscriptionID string) (*SubscriptionResponse, error) {
-	return c.service.Subscription.Retrieve(customerID, subscriptionID)
+	return c.GetSubscriptionWithContext(context.Background(), customerID, subscriptionID)
+}
+
+// GetSubscriptionWithContext はcontext.Contextを指定して顧客の特定の定期課金情報を取得します。
+func (c CustomerService) GetSubscriptionWithContext(ctx context.Context, customerID, subscriptionID string) (*SubscriptionResponse, error) {
+	result, err := c.service.call(ctx, "subscription.retrieve", subscriptionID, func(ctx context.Context) (interface{}, error) {
+		return c.service.Subscription.RetrieveWithContext(ctx, customerID, subscriptionID)
+	})
+	response, _ := result.(*SubscriptionResponse)
+	return response, err
 }
 
 // ListSubscription は顧客の定期課金リストを取得します。リストは、直近で生成された順番に取得されます。
@@ -268,24 +317,100 @@ func (c *CustomerListCaller) Until(until time.Time) *CustomerListCaller {
 
 // Do は指定されたクエリーを元に顧客のリストを配列で取得します。
 func (c *CustomerListCaller) Do() ([]*CustomerResponse, bool, error) {
-	body, err := c.service.queryList("/customers", c.limit, c.offset, c.since, c.until)
-	if err != nil {
-		return nil, false, err
-	}
-	raw := &listResponseParser{}
-	err = json.Unmarshal(body, raw)
-	if err != nil {
-		return nil, false, err
-	}
-	result := make([]*CustomerResponse, len(raw.Data))
+	return c.DoContext(context.Background())
+}
 
-	for i, raw := range raw.Data {
-		customer := &CustomerResponse{}
-		json.Unmarshal(raw, customer)
-		customer.service = c.service
-		result[i] = customer
-	}
-	return result, raw.HasMore, nil
+// DoContext はcontext.Contextを指定して顧客のリストを配列で取得します。
+func (c *CustomerListCaller) DoContext(ctx context.Context) ([]*CustomerResponse, bool, error) {
+	var hasMore bool
+	result, err := c.service.call(ctx, "customer.list", "", func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.queryList(ctx, "/customers", c.limit, c.offset, c.since, c.until)
+		if err != nil {
+			return nil, err
+		}
+		raw := &listResponseParser{}
+		err = json.Unmarshal(body, raw)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]*CustomerResponse, len(raw.Data))
+
+		for i, raw := range raw.Data {
+			customer := &CustomerResponse{}
+			json.Unmarshal(raw, customer)
+			customer.service = c.service
+			result[i] = customer
+		}
+		hasMore = raw.HasMore
+		return result, nil
+	})
+	list, _ := result.([]*CustomerResponse)
+	return list, hasMore, err
+}
+
+// Iter は顧客のリストを順に取得するイテレータを返します。次のページは自動的に取得されます。
+func (c *CustomerListCaller) Iter() *CustomerIter {
+	return c.IterContext(context.Background())
+}
+
+// IterContext はcontext.Contextを指定してイテレータを返します。ctxがキャンセルされると取得を中断します。
+func (c *CustomerListCaller) IterContext(ctx context.Context) *CustomerIter {
+	caller := *c
+	return &CustomerIter{newListIter(ctx, c.offset, c.limit, func(ctx context.Context, offset, limit int) ([]interface{}, bool, error) {
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	})}
+}
+
+// WindowIter は作成日時の時間窓をずらしながら顧客のリストを順に取得するイテレータを返します。
+//
+// Iter()はOffsetでページを送るため、取得中に新しい顧客が作成されると要素が重複したり欠落したりします。
+// WindowIter()はUntilを過去へ移動しながら取得し、重複をIDで取り除くため、
+// Since/Untilで指定した期間の顧客を過不足なく取得できます。Offsetの指定は無視されます。
+func (c *CustomerListCaller) WindowIter() *CustomerIter {
+	return c.WindowIterContext(context.Background())
+}
+
+// WindowIterContext はcontext.Contextを指定してWindowIter()と同様のイテレータを返します。
+func (c *CustomerListCaller) WindowIterContext(ctx context.Context) *CustomerIter {
+	caller := *c
+	return &CustomerIter{newListIter(ctx, 0, c.limit, windowPages(c.until, func(ctx context.Context, until, offset, limit int) ([]interface{}, bool, error) {
+		caller.until = until
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	}, func(item interface{}) (string, int) {
+		value := item.(*CustomerResponse)
+		return value.ID, int(value.CreatedAt.Unix())
+	}))}
+}
+
+// CustomerIter は顧客のリストを順に取得するイテレータです。
+type CustomerIter struct {
+	listIter
+}
+
+// Max は取得する要素数の上限を設定します。0の場合は上限なしです。
+func (it *CustomerIter) Max(n int) *CustomerIter {
+	it.max = n
+	return it
+}
+
+// Value は現在の要素を返します。
+func (it *CustomerIter) Value() *CustomerResponse {
+	value, _ := it.current.(*CustomerResponse)
+	return value
 }
 
 // CustomerCardListCaller はカードのリスト取得に使用する構造体です。
@@ -329,22 +454,70 @@ func (c *CustomerCardListCaller) Until(until time.Time) *CustomerCardListCaller
 
 // Do は指定されたクエリーを元に支払いのリストを配列で取得します。
 func (c *CustomerCardListCaller) Do() ([]*CardResponse, bool, error) {
-	body, err := c.service.queryList("/customers/"+c.customerID+"/cards", c.limit, c.offset, c.since, c.until)
-	if err != nil {
-		return nil, false, err
-	}
-	raw := &listResponseParser{}
-	err = json.Unmarshal(body, raw)
-	if err != nil {
-		return nil, false, err
-	}
-	result := make([]*CardResponse, len(raw.Data))
-	for i, rawCustomer := range raw.Data {
-		card := &CardResponse{}
-		json.Unmarshal(rawCustomer, card)
-		result[i] = card
-	}
-	return result, raw.HasMore, nil
+	return c.DoContext(context.Background())
+}
+
+// DoContext はcontext.Contextを指定してカードのリストを配列で取得します。
+func (c *CustomerCardListCaller) DoContext(ctx context.Context) ([]*CardResponse, bool, error) {
+	var hasMore bool
+	result, err := c.service.call(ctx, "card.list", c.customerID, func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.queryList(ctx, "/customers/"+c.customerID+"/cards", c.limit, c.offset, c.since, c.until)
+		if err != nil {
+			return nil, err
+		}
+		raw := &listResponseParser{}
+		err = json.Unmarshal(body, raw)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]*CardResponse, len(raw.Data))
+		for i, rawCustomer := range raw.Data {
+			card := &CardResponse{}
+			json.Unmarshal(rawCustomer, card)
+			result[i] = card
+		}
+		hasMore = raw.HasMore
+		return result, nil
+	})
+	list, _ := result.([]*CardResponse)
+	return list, hasMore, err
+}
+
+// Iter はカードのリストを順に取得するイテレータを返します。次のページは自動的に取得されます。
+func (c *CustomerCardListCaller) Iter() *CardIter {
+	return c.IterContext(context.Background())
+}
+
+// IterContext はcontext.Contextを指定してイテレータを返します。ctxがキャンセルされると取得を中断します。
+func (c *CustomerCardListCaller) IterContext(ctx context.Context) *CardIter {
+	caller := *c
+	return &CardIter{newListIter(ctx, c.offset, c.limit, func(ctx context.Context, offset, limit int) ([]interface{}, bool, error) {
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	})}
+}
+
+// CardIter はカードのリストを順に取得するイテレータです。
+type CardIter struct {
+	listIter
+}
+
+// Max は取得する要素数の上限を設定します。0の場合は上限なしです。
+func (it *CardIter) Max(n int) *CardIter {
+	it.max = n
+	return it
+}
+
+// Value は現在の要素を返します。
+func (it *CardIter) Value() *CardResponse {
+	value, _ := it.current.(*CardResponse)
+	return value
 }
 
 // CustomerResponse はCustomerService.GetやCustomerService.Listで返される顧客を表す構造体です
@@ -379,11 +552,19 @@ type customerResponseParser struct {
 //
 // また default_card に保持しているカードIDを指定することで、メイン利用のカードを変更することもできます。
 func (c *CustomerResponse) Update(customer Customer) error {
-	body, err := c.service.Customer.update(c.ID, customer)
-	if err != nil {
-		return err
-	}
-	return json.Unmarshal(body, c)
+	return c.UpdateWithContext(context.Background(), customer)
+}
+
+// UpdateWithContext はcontext.Contextを指定して顧客情報を更新します。
+func (c *CustomerResponse) UpdateWithContext(ctx context.Context, customer Customer) error {
+	_, err := c.service.call(ctx, "customer.update", c.ID, func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.Customer.update(ctx, c.ID, customer)
+		if err != nil {
+			return nil, err
+		}
+		return c, json.Unmarshal(body, c)
+	})
+	return err
 }
 
 // Delete は生成した顧客情報を削除します。削除した顧客情報は、もう一度生成することができないためご注意ください。
@@ -391,31 +572,61 @@ func (c *CustomerResponse) Delete() error {
 	return c.service.Customer.Delete(c.ID)
 }
 
+// DeleteWithContext はcontext.Contextを指定して顧客情報を削除します。
+func (c *CustomerResponse) DeleteWithContext(ctx context.Context) error {
+	return c.service.Customer.DeleteWithContext(ctx, c.ID)
+}
+
 // AddCard はカード情報のパラメーターを指定して、新たにカードを追加します。ただし同じカード番号および同じ有効期限年/月のカードは、重複追加することができません。
 func (c *CustomerResponse) AddCard(card Card) (*CardResponse, error) {
 	return c.service.Customer.AddCard(c.ID, card)
 }
 
+// AddCardWithContext はcontext.Contextを指定して、カード情報のパラメーターからカードを追加します。
+func (c *CustomerResponse) AddCardWithContext(ctx context.Context, card Card) (*CardResponse, error) {
+	return c.service.Customer.AddCardWithContext(ctx, c.ID, card)
+}
+
 // AddCardToken はトークンIDを指定して、新たにカードを追加します。ただし同じカード番号および同じ有効期限年/月のカードは、重複追加することができません。
 func (c *CustomerResponse) AddCardToken(token string) (*CardResponse, error) {
 	return c.service.Customer.AddCardToken(c.ID, token)
 }
 
+// AddCardTokenWithContext はcontext.Contextを指定して、トークンIDからカードを追加します。
+func (c *CustomerResponse) AddCardTokenWithContext(ctx context.Context, token string) (*CardResponse, error) {
+	return c.service.Customer.AddCardTokenWithContext(ctx, c.ID, token)
+}
+
 // GetCard は顧客の特定のカード情報を取得します。
 func (c *CustomerResponse) GetCard(cardID string) (*CardResponse, error) {
 	return c.service.Customer.GetCard(c.ID, cardID)
 }
 
+// GetCardWithContext はcontext.Contextを指定して顧客の特定のカード情報を取得します。
+func (c *CustomerResponse) GetCardWithContext(ctx context.Context, cardID string) (*CardResponse, error) {
+	return c.service.Customer.GetCardWithContext(ctx, c.ID, cardID)
+}
+
 // UpdateCard は顧客の特定のカード情報を更新します。
 func (c CustomerResponse) UpdateCard(cardID string, card Card) (*CardResponse, error) {
 	return c.service.Customer.UpdateCard(c.ID, cardID, card)
 }
 
+// UpdateCardWithContext はcontext.Contextを指定して顧客の特定のカード情報を更新します。
+func (c CustomerResponse) UpdateCardWithContext(ctx context.Context, cardID string, card Card) (*CardResponse, error) {
+	return c.service.Customer.UpdateCardWithContext(ctx, c.ID, cardID, card)
+}
+
 // DeleteCard は顧客の特定のカードを削除します。
 func (c CustomerResponse) DeleteCard(cardID string) error {
 	return c.service.Customer.DeleteCard(c.ID, cardID)
 }
 
+// DeleteCardWithContext はcontext.Contextを指定して顧客の特定のカードを削除します。
+func (c CustomerResponse) DeleteCardWithContext(ctx context.Context, cardID string) error {
+	return c.service.Customer.DeleteCardWithContext(ctx, c.ID, cardID)
+}
+
 // ListCard は顧客の保持しているカードリストを取得します。リストは、直近で生成された順番に取得されます。
 func (c *CustomerResponse) ListCard() *CustomerCardListCaller {
 	return c.service.Customer.ListCard(c.ID)
@@ -426,6 +637,11 @@ func (c *CustomerResponse) GetSubscription(subscriptionID string) (*Subscription
 	return c.service.Customer.GetSubscription(c.ID, subscriptionID)
 }
 
+// GetSubscriptionWithContext はcontext.Contextを指定して顧客の特定の定期課金情報を取得します。
+func (c *CustomerResponse) GetSubscriptionWithContext(ctx context.Context, subscriptionID string) (*SubscriptionResponse, error) {
+	return c.service.Customer.GetSubscriptionWithContext(ctx, c.ID, subscriptionID)
+}
+
 // ListSubscription は顧客の定期課金リストを取得します。リストは、直近で生成された順番に取得されます。
 func (c *CustomerResponse) ListSubscription() *SubscriptionListCaller {
 	return c.service.Customer.ListSubscription(c.ID)
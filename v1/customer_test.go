@@ -144,7 +144,7 @@ func TestCustomerCreate(t *testing.T) {
 	mock, transport := NewMockClient(200, customerResponseJSON)
 	service := New("api-key", mock)
 	customer, err := service.Customer.Create(Customer{
-		Description: "test",
+		Description: String("test"),
 	})
 	if transport.URL != "https://api.pay.jp/v1/customers" {
 		t.Errorf("URL is wrong: %s", transport.URL)
@@ -167,7 +167,7 @@ func TestCustomerCreateError(t *testing.T) {
 	mock, _ := NewMockClient(400, customerErrorResponseJSON)
 	service := New("api-key", mock)
 	customer, err := service.Customer.Create(Customer{
-		Description: "test",
+		Description: String("test"),
 	})
 	if err == nil {
 		t.Error("err should not be nil")
@@ -203,7 +203,7 @@ func TestCustomerUpdate(t *testing.T) {
 	mock, transport := NewMockClient(200, customerResponseJSON)
 	service := New("api-key", mock)
 	customer, err := service.Customer.Update("cus_121673955bd7aa144de5a8f6c262", Customer{
-		Email: "test@mail.com",
+		Email: String("test@mail.com"),
 	})
 	if transport.URL != "https://api.pay.jp/v1/customers/cus_121673955bd7aa144de5a8f6c262" {
 		t.Errorf("URL is wrong: %s", transport.URL)
@@ -231,7 +231,7 @@ func TestCustomerUpdate2(t *testing.T) {
 		return
 	}
 	err = plan.Update(Customer{
-		Email: "test@mail.com",
+		Email: String("test@mail.com"),
 	})
 	if err != nil {
 		t.Errorf("err should be nil, but %v", err)
@@ -304,3 +304,20 @@ func TestCustomerList(t *testing.T) {
 		t.Error("parse error: plans")
 	}
 }
+
+func TestCustomerUpdateClearsEmptyString(t *testing.T) {
+	server, form := newFormRecordingServer(customerResponseJSON)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+	_, err := service.Customer.Update("cus_121673955bd7aa144de5a8f6c262", Customer{
+		Email:       String(""),
+		DefaultCard: String("car_f7d9fa98594dc7c2e42bfcd641ff"),
+		ID:          String("ignored"),
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if form.Encode() != "default_card=car_f7d9fa98594dc7c2e42bfcd641ff&email=" {
+		t.Errorf("form is wrong: %s", form.Encode())
+	}
+}
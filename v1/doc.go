@@ -26,4 +26,37 @@
 //
 //   customer := pay.Customer.Retrieve("customer ID")
 //
+// Each API call has a variant that accepts context.Context (e.g. RetrieveWithContext, DoContext)
+// to support cancellation and deadlines:
+//
+//   ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//   defer cancel()
+//   customer, err := pay.Customer.RetrieveWithContext(ctx, "customer ID")
+//
+// Every API call passes through the interceptors registered with Service.Use or Config.Interceptors.
+// An interceptor sees the operation name (e.g. "charge.create"), the resource ID and the decoded
+// result or error, so logging, metrics and auditing can be added without parsing URLs:
+//
+//   pay.Use(func(next payjp.RoundTrip) payjp.RoundTrip {
+//       return func(ctx context.Context, call *payjp.Call) (interface{}, error) {
+//           result, err := next(ctx, call)
+//           log.Println(call.Operation, call.ResourceID, call.StatusCode, err)
+//           return result, err
+//       }
+//   })
+//
+// The status code, headers and raw body of a response, including the PAY.JP request ID,
+// can be captured with WithResponse:
+//
+//   var meta payjp.ResponseMetadata
+//   charge, err := pay.Charge.RetrieveWithContext(payjp.WithResponse(ctx, &meta), "charge ID")
+//   log.Println(meta.RequestID())
+//
+// Package payjptest provides an in-memory fake of the API for offline tests.
+// Point Config.APIBase at it:
+//
+//   server := payjptest.NewServer()
+//   defer server.Close()
+//   pay := payjp.New("sk_test_xxxxx", nil, payjp.Config{APIBase: server.URL})
+//
 package payjp
@@ -1,6 +1,7 @@
 package payjp
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/url"
@@ -71,15 +72,37 @@ func newEventService(service *Service) *EventService {
 
 // Retrieve event object. 特定のイベント情報を取得します。
 func (e EventService) Retrieve(id string) (*EventResponse, error) {
-	data, err := e.service.retrieve("/events/" + id)
-	if err != nil {
-		return nil, err
-	}
+	return e.RetrieveWithContext(context.Background(), id)
+}
+
+// RetrieveWithContext はcontext.Contextを指定して特定のイベント情報を取得します。
+func (e EventService) RetrieveWithContext(ctx context.Context, id string) (*EventResponse, error) {
+	result, err := e.service.call(ctx, "event.retrieve", id, func(ctx context.Context) (interface{}, error) {
+		data, err := e.service.retrieve(ctx, "/events/"+id)
+		if err != nil {
+			return nil, err
+		}
+		result := &EventResponse{}
+		err = json.Unmarshal(data, result)
+		if err != nil {
+			return nil, err
+		}
+		result.service = e.service
+		return result, nil
+	})
+	response, _ := result.(*EventResponse)
+	return response, err
+}
+
+// Parse はWebhookなどで受け取ったイベントのJSONをパースします。
+//
+// 返されたイベントのChargeData()などで取得したオブジェクトは、このサービスを使ってCapture()などを呼び出せます。
+func (e EventService) Parse(data []byte) (*EventResponse, error) {
 	result := &EventResponse{}
-	err = json.Unmarshal(data, result)
-	if err != nil {
+	if err := json.Unmarshal(data, result); err != nil {
 		return nil, err
 	}
+	result.service = e.service
 	return result, nil
 }
 
@@ -146,37 +169,114 @@ func (e *EventListCaller) Until(until time.Time) *EventListCaller {
 
 // Do は指定されたクエリーを元にイベントのリストを配列で取得します。
 func (e *EventListCaller) Do() ([]*EventResponse, bool, error) {
-	body, err := e.service.queryList("/events", e.limit, e.offset, e.since, e.until, func(values *url.Values) bool {
-		hasParam := false
-		if e.resourceID != "" {
-			values.Set("resource_id", e.resourceID)
-			hasParam = true
+	return e.DoContext(context.Background())
+}
+
+// DoContext はcontext.Contextを指定してイベントのリストを配列で取得します。
+func (e *EventListCaller) DoContext(ctx context.Context) ([]*EventResponse, bool, error) {
+	var hasMore bool
+	result, err := e.service.call(ctx, "event.list", "", func(ctx context.Context) (interface{}, error) {
+		body, err := e.service.queryList(ctx, "/events", e.limit, e.offset, e.since, e.until, func(values *url.Values) bool {
+			hasParam := false
+			if e.resourceID != "" {
+				values.Set("resource_id", e.resourceID)
+				hasParam = true
+			}
+			if e.object != "" {
+				values.Set("object", e.object)
+				hasParam = true
+			}
+			if e.typeString != "" {
+				values.Set("type", e.typeString)
+				hasParam = true
+			}
+			return hasParam
+		})
+		if err != nil {
+			return nil, err
 		}
-		if e.object != "" {
-			values.Set("object", e.object)
-			hasParam = true
+		raw := &listResponseParser{}
+		err = json.Unmarshal(body, raw)
+		if err != nil {
+			return nil, err
 		}
-		if e.typeString != "" {
-			values.Set("type", e.typeString)
-			hasParam = true
+		result := make([]*EventResponse, len(raw.Data))
+		for i, rawPlan := range raw.Data {
+			event := &EventResponse{}
+			json.Unmarshal(rawPlan, event)
+			event.service = e.service
+			result[i] = event
 		}
-		return hasParam
+		hasMore = raw.HasMore
+		return result, nil
 	})
-	if err != nil {
-		return nil, false, err
-	}
-	raw := &listResponseParser{}
-	err = json.Unmarshal(body, raw)
-	if err != nil {
-		return nil, false, err
-	}
-	result := make([]*EventResponse, len(raw.Data))
-	for i, rawPlan := range raw.Data {
-		event := &EventResponse{}
-		json.Unmarshal(rawPlan, event)
-		result[i] = event
-	}
-	return result, raw.HasMore, nil
+	list, _ := result.([]*EventResponse)
+	return list, hasMore, err
+}
+
+// Iter はイベントのリストを順に取得するイテレータを返します。次のページは自動的に取得されます。
+func (e *EventListCaller) Iter() *EventIter {
+	return e.IterContext(context.Background())
+}
+
+// IterContext はcontext.Contextを指定してイテレータを返します。ctxがキャンセルされると取得を中断します。
+func (e *EventListCaller) IterContext(ctx context.Context) *EventIter {
+	caller := *e
+	return &EventIter{newListIter(ctx, e.offset, e.limit, func(ctx context.Context, offset, limit int) ([]interface{}, bool, error) {
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	})}
+}
+
+// WindowIter は作成日時の時間窓をずらしながらイベントのリストを順に取得するイテレータを返します。
+//
+// Iter()はOffsetでページを送るため、取得中に新しいイベントが作成されると要素が重複したり欠落したりします。
+// WindowIter()はUntilを過去へ移動しながら取得し、重複をIDで取り除くため、
+// Since/Untilで指定した期間のイベントを過不足なく取得できます。Offsetの指定は無視されます。
+func (e *EventListCaller) WindowIter() *EventIter {
+	return e.WindowIterContext(context.Background())
+}
+
+// WindowIterContext はcontext.Contextを指定してWindowIter()と同様のイテレータを返します。
+func (e *EventListCaller) WindowIterContext(ctx context.Context) *EventIter {
+	caller := *e
+	return &EventIter{newListIter(ctx, 0, e.limit, windowPages(e.until, func(ctx context.Context, until, offset, limit int) ([]interface{}, bool, error) {
+		caller.until = until
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	}, func(item interface{}) (string, int) {
+		value := item.(*EventResponse)
+		return value.ID, int(value.CreatedAt.Unix())
+	}))}
+}
+
+// EventIter はイベントのリストを順に取得するイテレータです。
+type EventIter struct {
+	listIter
+}
+
+// Max は取得する要素数の上限を設定します。0の場合は上限なしです。
+func (it *EventIter) Max(n int) *EventIter {
+	it.max = n
+	return it
+}
+
+// Value は現在の要素を返します。
+func (it *EventIter) Value() *EventResponse {
+	value, _ := it.current.(*EventResponse)
+	return value
 }
 
 // EventResponse は、EventService.Retrieve()/EventService.List()が返す構造体です。
@@ -188,7 +288,8 @@ type EventResponse struct {
 	PendingWebHooks int
 	ResultType      EventType
 
-	data json.RawMessage
+	data    json.RawMessage
+	service *Service
 }
 
 // ChargeData は、イベントの種類がChargeEventの時にChargeResponse構造体を返します。
@@ -198,6 +299,7 @@ func (e EventResponse) ChargeData() (*ChargeResponse, error) {
 	}
 	result := &ChargeResponse{}
 	json.Unmarshal(e.data, result)
+	result.service = e.service
 	return result, nil
 }
 
@@ -208,6 +310,7 @@ func (e EventResponse) TokenData() (*TokenResponse, error) {
 	}
 	result := &TokenResponse{}
 	json.Unmarshal(e.data, result)
+	result.Card.service = e.service
 	return result, nil
 }
 
@@ -216,9 +319,7 @@ func (e EventResponse) CustomerData() (*CustomerResponse, error) {
 	if e.ResultType != CustomerEvent {
 		return nil, errors.New("this event is not customer type")
 	}
-	result := &CustomerResponse{}
-	json.Unmarshal(e.data, result)
-	return result, nil
+	return parseCustomer(e.service, e.data, &CustomerResponse{})
 }
 
 // CardData は、イベントの種類がCardEventの時にCardResponse構造体を返します。
@@ -228,6 +329,13 @@ func (e EventResponse) CardData() (*CardResponse, error) {
 	}
 	result := &CardResponse{}
 	json.Unmarshal(e.data, result)
+	// 顧客のカードの場合、Update()/Delete()で使用する顧客IDがcustomerに含まれる
+	var owner struct {
+		Customer string `json:"customer"`
+	}
+	json.Unmarshal(e.data, &owner)
+	result.service = e.service
+	result.customerID = owner.Customer
 	return result, nil
 }
 
@@ -238,6 +346,7 @@ func (e EventResponse) PlanData() (*PlanResponse, error) {
 	}
 	result := &PlanResponse{}
 	json.Unmarshal(e.data, result)
+	result.service = e.service
 	return result, nil
 }
 
@@ -248,6 +357,7 @@ func (e EventResponse) SubscriptionData() (*SubscriptionResponse, error) {
 	}
 	result := &SubscriptionResponse{}
 	json.Unmarshal(e.data, result)
+	result.service = e.service
 	return result, nil
 }
 
@@ -258,6 +368,7 @@ func (e EventResponse) TransferData() (*TransferResponse, error) {
 	}
 	result := &TransferResponse{}
 	json.Unmarshal(e.data, result)
+	result.service = e.service
 	return result, nil
 }
 
@@ -0,0 +1,230 @@
+package payjp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// EventHandler はEventRouterに登録するイベントの処理関数です。
+type EventHandler func(ctx context.Context, event *EventResponse) error
+
+// EventRouter はイベントの種類ごとに登録されたハンドラへイベントを振り分けます。
+//
+// Webhookで受信したイベントにも、EventService.List()で取得したイベントにも使用できます:
+//
+//     router := payjp.NewEventRouter()
+//     router.OnCharge(func(ctx context.Context, charge *payjp.ChargeResponse) error {
+//         ...
+//     }, "charge.succeeded", "charge.captured")
+//     router.OnSubscription(func(ctx context.Context, subscription *payjp.SubscriptionResponse) error {
+//         ...
+//     }, "subscription.renewed")
+//
+//     // Webhook
+//     http.Handle("/webhook", webhook.NewHandler("whook_xxxxx", router.Dispatch))
+//
+//     // EventService
+//     events, _, err := pay.Event.List().Do()
+//     for _, event := range events {
+//         err = router.Dispatch(ctx, event)
+//     }
+type EventRouter struct {
+	handlers     map[string][]EventHandler
+	typeHandlers map[EventType][]EventHandler
+	fallbacks    []EventHandler
+}
+
+// NewEventRouter はEventRouterを生成します。
+func NewEventRouter() *EventRouter {
+	return &EventRouter{
+		handlers:     make(map[string][]EventHandler),
+		typeHandlers: make(map[EventType][]EventHandler),
+	}
+}
+
+// Handle はイベントの種類(e.g. charge.succeeded)を指定してハンドラを登録します。
+func (r *EventRouter) Handle(eventType string, handler EventHandler) {
+	r.handlers[eventType] = append(r.handlers[eventType], handler)
+}
+
+// HandleType はEventTypeを指定して、その型のデータを持つすべてのイベントのハンドラを登録します。
+func (r *EventRouter) HandleType(resultType EventType, handler EventHandler) {
+	r.typeHandlers[resultType] = append(r.typeHandlers[resultType], handler)
+}
+
+// Fallback はどのハンドラにも該当しないイベント(未知の種類のイベントを含む)のハンドラを登録します。
+func (r *EventRouter) Fallback(handler EventHandler) {
+	r.fallbacks = append(r.fallbacks, handler)
+}
+
+// handleTyped はnamesが省略された場合はresultTypeのすべてのイベントに、
+// 指定された場合はそれぞれのイベントにハンドラを登録します。
+// resultTypeのデータを持たないイベントが含まれる場合は、何も登録せずにエラーを返します。
+func (r *EventRouter) handleTyped(resultType EventType, names []string, handler EventHandler) error {
+	if len(names) == 0 {
+		r.HandleType(resultType, handler)
+		return nil
+	}
+	for _, eventType := range names {
+		if t, ok := eventTypes[eventType]; !ok || t != resultType {
+			return fmt.Errorf("payjp: event %q does not have the data of the handler", eventType)
+		}
+	}
+	for _, eventType := range names {
+		r.Handle(eventType, handler)
+	}
+	return nil
+}
+
+// OnCharge はChargeEventのイベントのハンドラを登録します。
+// eventTypesを省略すると、支払いに関するすべてのイベントが対象になります。
+// 支払いのデータを持たないイベントを指定した場合はエラーを返します。
+func (r *EventRouter) OnCharge(handler func(ctx context.Context, charge *ChargeResponse) error, eventTypes ...string) error {
+	return r.handleTyped(ChargeEvent, eventTypes, func(ctx context.Context, event *EventResponse) error {
+		data, err := event.ChargeData()
+		if err != nil {
+			return err
+		}
+		return handler(ctx, data)
+	})
+}
+
+// OnToken はTokenEventのイベントのハンドラを登録します。
+func (r *EventRouter) OnToken(handler func(ctx context.Context, token *TokenResponse) error, eventTypes ...string) error {
+	return r.handleTyped(TokenEvent, eventTypes, func(ctx context.Context, event *EventResponse) error {
+		data, err := event.TokenData()
+		if err != nil {
+			return err
+		}
+		return handler(ctx, data)
+	})
+}
+
+// OnCustomer はCustomerEventのイベントのハンドラを登録します。
+func (r *EventRouter) OnCustomer(handler func(ctx context.Context, customer *CustomerResponse) error, eventTypes ...string) error {
+	return r.handleTyped(CustomerEvent, eventTypes, func(ctx context.Context, event *EventResponse) error {
+		data, err := event.CustomerData()
+		if err != nil {
+			return err
+		}
+		return handler(ctx, data)
+	})
+}
+
+// OnCard はCardEventのイベントのハンドラを登録します。
+func (r *EventRouter) OnCard(handler func(ctx context.Context, card *CardResponse) error, eventTypes ...string) error {
+	return r.handleTyped(CardEvent, eventTypes, func(ctx context.Context, event *EventResponse) error {
+		data, err := event.CardData()
+		if err != nil {
+			return err
+		}
+		return handler(ctx, data)
+	})
+}
+
+// OnPlan はPlanEventのイベントのハンドラを登録します。
+func (r *EventRouter) OnPlan(handler func(ctx context.Context, plan *PlanResponse) error, eventTypes ...string) error {
+	return r.handleTyped(PlanEvent, eventTypes, func(ctx context.Context, event *EventResponse) error {
+		data, err := event.PlanData()
+		if err != nil {
+			return err
+		}
+		return handler(ctx, data)
+	})
+}
+
+// OnSubscription はSubscriptionEventのイベントのハンドラを登録します。
+func (r *EventRouter) OnSubscription(handler func(ctx context.Context, subscription *SubscriptionResponse) error, eventTypes ...string) error {
+	return r.handleTyped(SubscriptionEvent, eventTypes, func(ctx context.Context, event *EventResponse) error {
+		data, err := event.SubscriptionData()
+		if err != nil {
+			return err
+		}
+		return handler(ctx, data)
+	})
+}
+
+// OnTransfer はTransferEventのイベントのハンドラを登録します。
+func (r *EventRouter) OnTransfer(handler func(ctx context.Context, transfer *TransferResponse) error, eventTypes ...string) error {
+	return r.handleTyped(TransferEvent, eventTypes, func(ctx context.Context, event *EventResponse) error {
+		data, err := event.TransferData()
+		if err != nil {
+			return err
+		}
+		return handler(ctx, data)
+	})
+}
+
+// OnDelete はDeleteEventのイベントのハンドラを登録します。
+func (r *EventRouter) OnDelete(handler func(ctx context.Context, deleted *DeleteResponse) error, eventTypes ...string) error {
+	return r.handleTyped(DeleteEvent, eventTypes, func(ctx context.Context, event *EventResponse) error {
+		data, err := event.DeleteData()
+		if err != nil {
+			return err
+		}
+		return handler(ctx, data)
+	})
+}
+
+// Dispatch はイベントを該当するすべてのハンドラに渡します。
+//
+// イベントの種類で登録されたハンドラ、EventTypeで登録されたハンドラの順に呼び出し、
+// どれにも該当しない場合はFallbackで登録されたハンドラを呼び出します。
+// 一部のハンドラがエラーを返しても残りのハンドラは呼び出され、エラーはEventHandlerErrorsにまとめて返されます。
+func (r *EventRouter) Dispatch(ctx context.Context, event *EventResponse) error {
+	handlers := append([]EventHandler{}, r.handlers[event.Type]...)
+	if resultType, ok := eventTypes[event.Type]; ok {
+		handlers = append(handlers, r.typeHandlers[resultType]...)
+	}
+	if len(handlers) == 0 {
+		handlers = r.fallbacks
+	}
+	var errs EventHandlerErrors
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// EventHandlerErrors はEventRouter.Dispatch()で複数のハンドラが返したエラーをまとめたものです。
+type EventHandlerErrors []error
+
+func (e EventHandlerErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("payjp: %d event handler(s) failed: %s", len(e), strings.Join(messages, "; "))
+}
+
+// Is はいずれかのハンドラのエラーがtargetに該当するかを返します。errors.Isで使用します。
+func (e EventHandlerErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As はtargetに代入できる最初のハンドラのエラーを代入します。errors.Asで使用します。
+func (e EventHandlerErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap は個別のエラーを返します。Go 1.20以降のerrors.Is/errors.Asでも使用されます。
+func (e EventHandlerErrors) Unwrap() []error {
+	return e
+}
@@ -0,0 +1,202 @@
+package payjp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var unknownEventResponseJSON = []byte(`
+{
+  "created": 1442288882,
+  "data": {},
+  "id": "evnt_0a0a0a0a0a0a0a0a0a0a0a0a0a0",
+  "livemode": false,
+  "object": "event",
+  "pending_webhooks": 1,
+  "type": "unknown.happened"
+}
+`)
+
+func parseEvent(t *testing.T, data []byte) *EventResponse {
+	event := &EventResponse{}
+	if err := json.Unmarshal(data, event); err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	return event
+}
+
+func TestEventRouterTypedHandler(t *testing.T) {
+	router := NewEventRouter()
+	var customer *CustomerResponse
+	router.OnCustomer(func(ctx context.Context, c *CustomerResponse) error {
+		customer = c
+		return nil
+	}, "customer.updated")
+	router.OnCharge(func(ctx context.Context, c *ChargeResponse) error {
+		t.Error("charge handler should not be called")
+		return nil
+	})
+
+	err := router.Dispatch(context.Background(), parseEvent(t, eventResponseJSON))
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+	if customer == nil {
+		t.Fatal("customer handler should be called")
+	}
+	if customer.ID != "cus_a16c7b4df01168eb82557fe93de4" {
+		t.Errorf("customer.ID is wrong: %s", customer.ID)
+	}
+}
+
+func TestEventRouterHandlerOrder(t *testing.T) {
+	router := NewEventRouter()
+	var called []string
+	router.HandleType(CustomerEvent, func(ctx context.Context, event *EventResponse) error {
+		called = append(called, "type")
+		return nil
+	})
+	router.Handle("customer.updated", func(ctx context.Context, event *EventResponse) error {
+		called = append(called, "name")
+		return nil
+	})
+	router.Handle("customer.created", func(ctx context.Context, event *EventResponse) error {
+		called = append(called, "other")
+		return nil
+	})
+	router.Fallback(func(ctx context.Context, event *EventResponse) error {
+		called = append(called, "fallback")
+		return nil
+	})
+
+	router.Dispatch(context.Background(), parseEvent(t, eventResponseJSON))
+	if len(called) != 2 || called[0] != "name" || called[1] != "type" {
+		t.Errorf("handlers should be called by name and type, but %v", called)
+	}
+}
+
+func TestEventRouterFallback(t *testing.T) {
+	router := NewEventRouter()
+	router.OnCharge(func(ctx context.Context, c *ChargeResponse) error {
+		t.Error("charge handler should not be called for unknown event")
+		return nil
+	})
+	var fallback *EventResponse
+	router.Fallback(func(ctx context.Context, event *EventResponse) error {
+		fallback = event
+		return nil
+	})
+
+	router.Dispatch(context.Background(), parseEvent(t, unknownEventResponseJSON))
+	if fallback == nil || fallback.Type != "unknown.happened" {
+		t.Errorf("fallback should be called, but %v", fallback)
+	}
+}
+
+func TestEventRouterNoHandler(t *testing.T) {
+	router := NewEventRouter()
+	if err := router.Dispatch(context.Background(), parseEvent(t, unknownEventResponseJSON)); err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+}
+
+func TestEventRouterErrors(t *testing.T) {
+	router := NewEventRouter()
+	err1 := errors.New("first")
+	err2 := errors.New("second")
+	called := 0
+	router.Handle("customer.updated", func(ctx context.Context, event *EventResponse) error {
+		called++
+		return err1
+	})
+	router.OnCustomer(func(ctx context.Context, c *CustomerResponse) error {
+		called++
+		return nil
+	})
+	router.HandleType(CustomerEvent, func(ctx context.Context, event *EventResponse) error {
+		called++
+		return err2
+	})
+
+	err := router.Dispatch(context.Background(), parseEvent(t, eventResponseJSON))
+	if called != 3 {
+		t.Errorf("all handlers should be called, but %d", called)
+	}
+	var errs EventHandlerErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("err should be EventHandlerErrors, but %v", err)
+	}
+	if len(errs) != 2 || errs[0] != err1 || errs[1] != err2 {
+		t.Errorf("errors are wrong: %v", errs)
+	}
+}
+
+func TestEventRouterErrorsIsAs(t *testing.T) {
+	router := NewEventRouter()
+	router.Handle("customer.updated", func(ctx context.Context, event *EventResponse) error {
+		return errors.New("first")
+	})
+	router.Handle("customer.updated", func(ctx context.Context, event *EventResponse) error {
+		return fmt.Errorf("second: %w", &Error{Status: 402, Type: "card_error", Code: "card_declined"})
+	})
+
+	err := router.Dispatch(context.Background(), parseEvent(t, eventResponseJSON))
+	if !errors.Is(err, ErrCardError) {
+		t.Errorf("errors.Is should find card error in handler errors: %v", err)
+	}
+	if errors.Is(err, ErrRateLimit) {
+		t.Error("errors.Is should not match other errors")
+	}
+	var payjpError *Error
+	if !errors.As(err, &payjpError) || payjpError.Code != "card_declined" {
+		t.Errorf("errors.As should find *Error in handler errors: %v", err)
+	}
+}
+
+func TestEventRouterInvalidEventType(t *testing.T) {
+	router := NewEventRouter()
+	handler := func(ctx context.Context, c *ChargeResponse) error {
+		return nil
+	}
+	if err := router.OnCharge(handler, "charge.succeeded", "customer.updated"); err == nil {
+		t.Error("registering charge handler for customer event should return error")
+	}
+	if err := router.OnCharge(handler, "charge.unknown"); err == nil {
+		t.Error("registering handler for unknown event should return error")
+	}
+	if len(router.handlers) != 0 {
+		t.Errorf("no handler should be registered, but %v", router.handlers)
+	}
+	if err := router.OnCharge(handler, "charge.succeeded"); err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+}
+
+func TestEventRouterListedEvents(t *testing.T) {
+	mock, _ := NewMockClient(200, eventListResponseJSON)
+	service := New("api-key", mock)
+	events, _, err := service.Event.List().Do()
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	router := NewEventRouter()
+	var charge *ChargeResponse
+	router.OnCharge(func(ctx context.Context, c *ChargeResponse) error {
+		charge = c
+		return nil
+	}, "charge.updated")
+	for _, event := range events {
+		if err := router.Dispatch(context.Background(), event); err != nil {
+			t.Errorf("err should be nil, but %v", err)
+		}
+	}
+	if charge == nil {
+		t.Fatal("charge handler should be called")
+	}
+	if charge.service != service {
+		t.Error("charge from listed event should be bound to service")
+	}
+}
@@ -26,12 +26,12 @@ func main() {
 	fmt.Println("Plan:", plan)
 
 	setSubscr, err := service.Subscription.Update(id, payjp.Subscription{
-		NextCyclePlanID: plan.ID,
+		NextCyclePlanID: payjp.String(plan.ID),
 	})
 	fmt.Println("NextCyclePlan:", setSubscr.NextCyclePlan)
 
 	delSubscr, err := service.Subscription.Update(id, payjp.Subscription{
-		NextCyclePlanID: "",
+		NextCyclePlanID: payjp.String(""),
 	})
 	fmt.Println("NextCyclePlan:", delSubscr.NextCyclePlan)
 	if service.Plan.Delete(plan.ID) != nil {
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/payjp/payjp-go/v1"
+	"github.com/payjp/payjp-go/v1/webhook"
+)
+
+func main() {
+	// イベントの種類ごとにハンドラを登録します
+	router := payjp.NewEventRouter()
+	err := router.OnCharge(func(ctx context.Context, charge *payjp.ChargeResponse) error {
+		fmt.Println("Charge succeeded:", charge.ID, charge.Amount)
+		return nil
+	}, "charge.succeeded")
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = router.OnSubscription(func(ctx context.Context, subscription *payjp.SubscriptionResponse) error {
+		fmt.Println("Subscription renewed:", subscription.ID)
+		return nil
+	}, "subscription.renewed")
+	if err != nil {
+		log.Fatal(err)
+	}
+	router.Fallback(func(ctx context.Context, event *payjp.EventResponse) error {
+		fmt.Println("Event:", event.ID, event.Type)
+		return nil
+	})
+
+	// PAY.JPの管理画面で確認できるWebhookトークンを指定します
+	handler := webhook.NewHandler("whook_xxxxx", router.Dispatch)
+	handler.ErrorLog = func(r *http.Request, err error) {
+		log.Println("webhook error:", err)
+	}
+
+	http.Handle("/webhook", handler)
+	log.Fatal(http.ListenAndServe(":8080", nil))
+}
@@ -0,0 +1,134 @@
+// Package expvarmetrics はAPI呼び出しの計測値をexpvarで公開するpayjp.Metricsの実装です。
+//
+//     metrics := expvarmetrics.New("payjp")
+//     pay := payjp.New("sk_test_xxxxx", nil, payjp.Config{Metrics: metrics})
+//
+// 計測値は/debug/varsの"payjp"以下に、次の形式で公開されます:
+//
+//     {
+//       "requests": {"charge.create": 10, ...},              // 操作ごとの呼び出し回数
+//       "errors": {"charge.create": {"card_error/card_declined": 2, "http_502": 1, ...}, ...}, // 操作ごとのエラーのtype/codeの回数
+//       "latency": {"charge.create": {"le_100ms": 3, ..., "le_inf": 10, "count": 10, "sum_ms": 1520}, ...}
+//     }
+//
+// PAY.JPのエラー形式でないレスポンス(プロキシが返したエラーページなど)は"http_<ステータス>"で集計されます。
+// latencyの"le_<上限>"は、その時間以下で完了した呼び出しの累積回数です。
+package expvarmetrics
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/payjp/payjp-go/v1"
+)
+
+// LatencyBuckets はレイテンシのヒストグラムのバケットの上限です。変更はその後に作成したMetricsに適用されます。
+var LatencyBuckets = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Metrics はexpvarで計測値を公開するpayjp.Metricsです。
+type Metrics struct {
+	vars     *expvar.Map
+	requests *expvar.Map
+	errors   *expvar.Map
+	latency  *expvar.Map
+	bounds   []time.Duration
+	buckets  []string
+
+	mu sync.Mutex
+}
+
+// New はnameでexpvarに公開されたMetricsを返します。
+// expvar.Publishと同様に、同じnameで二度呼ぶとpanicします。
+func New(name string) *Metrics {
+	m := NewUnpublished()
+	expvar.Publish(name, m.vars)
+	return m
+}
+
+// NewUnpublished はexpvarに公開しないMetricsを返します。Varで取得した値を任意の名前で公開できます。
+func NewUnpublished() *Metrics {
+	m := &Metrics{
+		vars:     new(expvar.Map).Init(),
+		requests: new(expvar.Map).Init(),
+		errors:   new(expvar.Map).Init(),
+		latency:  new(expvar.Map).Init(),
+	}
+	m.vars.Set("requests", m.requests)
+	m.vars.Set("errors", m.errors)
+	m.vars.Set("latency", m.latency)
+	m.bounds = append(m.bounds, LatencyBuckets...)
+	for _, bound := range m.bounds {
+		m.buckets = append(m.buckets, "le_"+bound.String())
+	}
+	return m
+}
+
+// Var はすべての計測値を含むexpvar.Varを返します。
+func (m *Metrics) Var() expvar.Var {
+	return m.vars
+}
+
+// RecordCall はAPI呼び出しの計測値を記録します。
+func (m *Metrics) RecordCall(ctx context.Context, metrics *payjp.CallMetrics) {
+	m.requests.Add(metrics.Operation, 1)
+
+	if metrics.Err != nil {
+		m.subMap(m.errors, metrics.Operation).Add(errorKey(metrics), 1)
+	}
+
+	histogram := m.subMap(m.latency, metrics.Operation)
+	for i, bound := range m.bounds {
+		if metrics.Latency <= bound {
+			histogram.Add(m.buckets[i], 1)
+		}
+	}
+	histogram.Add("le_inf", 1)
+	histogram.Add("count", 1)
+	histogram.AddFloat("sum_ms", float64(metrics.Latency)/float64(time.Millisecond))
+}
+
+// subMap はparentのkeyに対応するexpvar.Mapを返します。存在しない場合は作成します。
+func (m *Metrics) subMap(parent *expvar.Map, key string) *expvar.Map {
+	if v, ok := parent.Get(key).(*expvar.Map); ok {
+		return v
+	}
+	// 同時に作成された場合も同じMapを使うよう、作成はロックの内側で行う
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if v, ok := parent.Get(key).(*expvar.Map); ok {
+		return v
+	}
+	v := new(expvar.Map).Init()
+	parent.Set(key, v)
+	return v
+}
+
+func errorKey(metrics *payjp.CallMetrics) string {
+	var payjpError *payjp.Error
+	switch {
+	case metrics.ErrorType != "" && metrics.ErrorCode != "":
+		return metrics.ErrorType + "/" + metrics.ErrorCode
+	case metrics.ErrorType != "":
+		return metrics.ErrorType
+	case errors.As(metrics.Err, &payjpError) && payjpError.Status != 0:
+		return "http_" + strconv.Itoa(payjpError.Status)
+	case errors.Is(metrics.Err, payjp.ErrNetwork):
+		return "network_error"
+	case errors.Is(metrics.Err, context.Canceled), errors.Is(metrics.Err, context.DeadlineExceeded):
+		return "canceled"
+	}
+	return "other"
+}
@@ -0,0 +1,83 @@
+package expvarmetrics
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+	"time"
+
+	payjp "github.com/payjp/payjp-go/v1"
+	"github.com/payjp/payjp-go/v1/payjptest"
+)
+
+type snapshot struct {
+	Requests map[string]int                `json:"requests"`
+	Errors   map[string]map[string]int     `json:"errors"`
+	Latency  map[string]map[string]float64 `json:"latency"`
+}
+
+func read(t *testing.T, v expvar.Var) snapshot {
+	var s snapshot
+	if err := json.Unmarshal([]byte(v.String()), &s); err != nil {
+		t.Fatalf("expvar should be valid JSON, but %v: %s", err, v.String())
+	}
+	return s
+}
+
+func TestMetrics(t *testing.T) {
+	server := payjptest.NewServer()
+	defer server.Close()
+	metrics := NewUnpublished()
+	service := payjp.New("sk_test_xxxxx", nil, payjp.Config{APIBase: server.URL, Metrics: metrics})
+
+	card := payjp.Card{ExpMonth: payjp.Int(12), ExpYear: payjp.Int(time.Now().Year() + 1)}
+	card.Number = payjp.String("4242424242424242")
+	if _, err := service.Token.Create(card); err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	card.Number = payjp.String("4000000000000002")
+	if _, err := service.Token.Create(card); !payjp.IsCardDeclined(err) {
+		t.Fatalf("err should be card_declined, but %v", err)
+	}
+	if _, err := service.Charge.Retrieve("ch_xxxxx"); err == nil {
+		t.Fatal("err should not be nil")
+	}
+
+	s := read(t, metrics.Var())
+	if s.Requests["token.create"] != 2 || s.Requests["charge.retrieve"] != 1 {
+		t.Errorf("requests are wrong: %v", s.Requests)
+	}
+	if s.Errors["token.create"]["card_error/card_declined"] != 1 {
+		t.Errorf("errors are wrong: %v", s.Errors)
+	}
+	if s.Errors["charge.retrieve"]["invalid_request_error/invalid_id"] != 1 {
+		t.Errorf("errors are wrong: %v", s.Errors)
+	}
+	histogram := s.Latency["token.create"]
+	if histogram["count"] != 2 || histogram["le_inf"] != 2 || histogram["le_10s"] != 2 || histogram["sum_ms"] <= 0 {
+		t.Errorf("latency is wrong: %v", histogram)
+	}
+}
+
+func TestErrorKey(t *testing.T) {
+	cases := []struct {
+		metrics payjp.CallMetrics
+		key     string
+	}{
+		{payjp.CallMetrics{ErrorType: "card_error", ErrorCode: "card_declined"}, "card_error/card_declined"},
+		{payjp.CallMetrics{ErrorType: "server_error"}, "server_error"},
+		{payjp.CallMetrics{Err: &payjp.NetworkError{Err: expvarTestError("reset")}}, "network_error"},
+		{payjp.CallMetrics{StatusCode: 502, Err: &payjp.Error{Status: 502, Body: "<html>Bad Gateway</html>"}}, "http_502"},
+		{payjp.CallMetrics{Err: &payjp.Error{Code: "invalid_number"}}, "other"},
+		{payjp.CallMetrics{Err: expvarTestError("parameter error")}, "other"},
+	}
+	for _, c := range cases {
+		if key := errorKey(&c.metrics); key != c.key {
+			t.Errorf("key should be %s, but %s", c.key, key)
+		}
+	}
+}
+
+type expvarTestError string
+
+func (e expvarTestError) Error() string { return string(e) }
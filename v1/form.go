@@ -0,0 +1,152 @@
+package payjp
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// encodeForm はformタグを元に構造体をフォームの値に変換します。
+//
+// タグは `form:"name"` または `form:"name,omitempty"` の形式で指定し、`form:"-"` のフィールドとタグのないフィールドは無視します。
+// 値は次のように変換されます:
+//
+//   - nilのポインタとinterface{}は送信しません。nilでなければゼロ値でも送信するため、空文字列を指定して値を消去できます
+//   - 構造体とmapは name[key] の形式になります。mapのキーは辞書順に並べます
+//   - 埋め込まれた構造体のフィールドは、外側の構造体のフィールドとして扱います
+//   - time.TimeはUNIXタイムスタンプ(秒)になります
+//   - Metadataは送信前にValidateで検証し、エラーがあればそのエラーを返します
+//   - omitemptyを指定したフィールドは、ゼロ値の場合(ポインタとinterface{}では指す先がゼロ値の場合)に送信しません
+func encodeForm(params interface{}) (url.Values, error) {
+	values := url.Values{}
+	v := reflect.ValueOf(params)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return values, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("payjp: form params should be a struct, but %s", v.Type())
+	}
+	if err := encodeStruct(values, "", v); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	metadataType = reflect.TypeOf(Metadata{})
+)
+
+func formKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "[" + name + "]"
+}
+
+func encodeStruct(values url.Values, prefix string, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup("form")
+		if field.Anonymous && !hasTag {
+			fv := v.Field(i)
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := encodeStruct(values, prefix, fv); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !hasTag || tag == "-" || field.PkgPath != "" {
+			continue
+		}
+		name := tag
+		omitEmpty := false
+		if comma := strings.Index(tag, ","); comma != -1 {
+			name = tag[:comma]
+			omitEmpty = tag[comma+1:] == "omitempty"
+		}
+		if err := encodeValue(values, formKey(prefix, name), v.Field(i), omitEmpty); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeValue(values url.Values, key string, v reflect.Value, omitEmpty bool) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		// ポインタやinterface{}で指定された値は、ゼロ値でも明示的に指定されたものとして扱う
+		return encodeValue(values, key, v.Elem(), omitEmpty)
+	}
+	if omitEmpty && v.IsZero() {
+		return nil
+	}
+	if v.Type() == metadataType {
+		if err := v.Interface().(Metadata).Validate(); err != nil {
+			return err
+		}
+	}
+	if v.Type() == timeType {
+		if v.IsZero() {
+			return nil
+		}
+		values.Add(key, strconv.FormatInt(v.Interface().(time.Time).Unix(), 10))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		values.Add(key, v.String())
+	case reflect.Bool:
+		values.Add(key, strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		values.Add(key, strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		values.Add(key, strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		values.Add(key, strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()))
+	case reflect.Struct:
+		return encodeStruct(values, key, v)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("payjp: form map key should be string, but %s (%s)", v.Type().Key(), key)
+		}
+		keys := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			err := encodeValue(values, formKey(key, k), v.MapIndex(reflect.ValueOf(k).Convert(v.Type().Key())), false)
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("payjp: unsupported form value type %s (%s)", v.Type(), key)
+	}
+	return nil
+}
+
+// formBody はフォームの値をリクエストボディに変換します。
+func formBody(values url.Values) io.Reader {
+	return strings.NewReader(values.Encode())
+}
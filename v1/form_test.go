@@ -0,0 +1,77 @@
+package payjp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEncodeForm(t *testing.T) {
+	amount := int64(0)
+	values, err := encodeForm(struct {
+		Amount    *int64            `form:"amount"`
+		Skipped   *string           `form:"skipped"`
+		Fee       int64             `form:"fee"`
+		Rate      float64           `form:"rate"`
+		Capture   bool              `form:"capture"`
+		Empty     string            `form:"empty,omitempty"`
+		Clear     interface{}       `form:"clear"`
+		TrialEnd  time.Time         `form:"trial_end"`
+		Card      Card              `form:"card"`
+		Metadata  map[string]string `form:"metadata"`
+		Ignored   string            `form:"-"`
+		untagged  string
+		NotTagged string
+	}{
+		Amount:   &amount,
+		Fee:      1 << 40,
+		Rate:     10.15,
+		Clear:    "",
+		TrialEnd: time.Unix(1455328095, 0),
+		Card: Card{
+			Number:   String("4242424242424242"),
+			ExpMonth: Int(2),
+		},
+		Metadata: map[string]string{"b": "2", "a": "1", "deleted": ""},
+		Ignored:  "ignored",
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	expected := "amount=0&capture=false&card%5Bexp_month%5D=2&card%5Bnumber%5D=4242424242424242&clear=&fee=1099511627776&metadata%5Ba%5D=1&metadata%5Bb%5D=2&metadata%5Bdeleted%5D=&rate=10.15&trial_end=1455328095"
+	if values.Encode() != expected {
+		t.Errorf("form is wrong: %s", values.Encode())
+	}
+}
+
+func TestEncodeFormEmbedded(t *testing.T) {
+	values, err := encodeForm(struct {
+		Amount int `form:"amount"`
+		Charge
+	}{1000, Charge{CardToken: "tok_xxxxx", Currency: "jpy", Capture: true}})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	expected := "amount=1000&capture=true&card=tok_xxxxx&currency=jpy&description="
+	if values.Encode() != expected {
+		t.Errorf("form is wrong: %s", values.Encode())
+	}
+}
+
+func TestEncodeFormUnsupported(t *testing.T) {
+	_, err := encodeForm(struct {
+		List []string `form:"list"`
+	}{[]string{"a"}})
+	if err == nil {
+		t.Error("err should not be nil for unsupported types")
+	}
+	_, err = encodeForm(struct {
+		Name interface{} `form:"name"`
+	}{[]int{1}})
+	if err == nil {
+		t.Error("err should not be nil for unsupported interface{} values")
+	}
+	_, err = encodeForm("name")
+	if err == nil {
+		t.Error("err should not be nil for non-struct params")
+	}
+}
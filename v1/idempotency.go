@@ -0,0 +1,72 @@
+package payjp
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sync"
+)
+
+type idempotencyKeyContextKey struct{}
+
+// idempotencyKeyHolder はWithIdempotencyKeyで指定されたキーと、使用済みかどうかを保持します。
+type idempotencyKeyHolder struct {
+	mu   sync.Mutex
+	key  string
+	used bool
+}
+
+// WithIdempotencyKey はIdempotency-Keyヘッダーに指定するキーを設定したcontext.Contextを返します。
+//
+// 返されたcontext.ContextをCreateWithContextなどに渡すと、最初のPOSTリクエストにのみキーが付与されます。
+// 同じcontext.Context(またはそこから派生したもの)で続けて送信したPOSTリクエストには付与されません。
+// 同じキーを持つリクエストはPAY.JP側で一度だけ処理されるため、タイムアウト後は同じキーで
+// 再度WithIdempotencyKeyを呼ぶことで安全に再送できます:
+//
+//     ctx := payjp.WithIdempotencyKey(context.Background(), "order-1234")
+//     charge, err := pay.Charge.CreateWithContext(ctx, 1000, payjp.Charge{CardToken: "tok_xxxxx"})
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, &idempotencyKeyHolder{key: key})
+}
+
+// consumeIdempotencyKey はctxに設定された未使用のキーを返し、使用済みにします。
+func consumeIdempotencyKey(ctx context.Context) string {
+	holder, ok := ctx.Value(idempotencyKeyContextKey{}).(*idempotencyKeyHolder)
+	if !ok {
+		return ""
+	}
+	holder.mu.Lock()
+	defer holder.mu.Unlock()
+	if holder.used {
+		return ""
+	}
+	holder.used = true
+	return holder.key
+}
+
+// idempotencyKey はリクエストに付与するIdempotency-Keyを返します。
+// キーはリクエストごとに一度だけ決定され、再試行時にも同じものが使われます。
+// WithIdempotencyKeyで指定されたキーは、最初のPOSTリクエストでのみ使われます。
+func (s Service) idempotencyKey(ctx context.Context, method string) (string, error) {
+	if method != "POST" {
+		return "", nil
+	}
+	if key := consumeIdempotencyKey(ctx); key != "" {
+		return key, nil
+	}
+	if !s.autoIdempotencyKey {
+		return "", nil
+	}
+	return newIdempotencyKey()
+}
+
+// newIdempotencyKey はランダムなUUID(v4)形式のキーを生成します。
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
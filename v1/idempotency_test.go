@@ -0,0 +1,106 @@
+package payjp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func newKeyRecordingServer(failures int) (*httptest.Server, *[]string) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(chargeResponseJSON)
+	}))
+	return server, &keys
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	server, keys := newKeyRecordingServer(1)
+	defer server.Close()
+	service := New("api-key", nil, Config{
+		APIBase:           server.URL,
+		MaxRetries:        2,
+		RetryInitialDelay: time.Millisecond,
+	})
+	ctx := WithIdempotencyKey(context.Background(), "order-1234")
+	_, err := service.Charge.CreateWithContext(ctx, 1000, Charge{CardToken: "tok_xxxxx"})
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+	if len(*keys) != 2 {
+		t.Fatalf("request with Idempotency-Key should be retried, but sent %d times", len(*keys))
+	}
+	for _, key := range *keys {
+		if key != "order-1234" {
+			t.Errorf(`Idempotency-Key should be "order-1234", but "%s"`, key)
+		}
+	}
+}
+
+func TestIdempotencyKeyUsedOnce(t *testing.T) {
+	server, keys := newKeyRecordingServer(0)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+	ctx := WithIdempotencyKey(context.Background(), "order-1234")
+	service.Charge.CreateWithContext(ctx, 1000, Charge{CardToken: "tok_xxxxx"})
+	derived, cancel := context.WithCancel(ctx)
+	defer cancel()
+	service.Charge.CaptureWithContext(derived, "ch_fa990a4c10672a93053a774730b0a")
+	if len(*keys) != 2 {
+		t.Fatalf("request count should be 2, but %d", len(*keys))
+	}
+	if (*keys)[0] != "order-1234" {
+		t.Errorf(`Idempotency-Key should be "order-1234", but "%s"`, (*keys)[0])
+	}
+	if (*keys)[1] != "" {
+		t.Errorf("second POST should not reuse Idempotency-Key, but %s", (*keys)[1])
+	}
+}
+
+func TestIdempotencyKeyNotSentByDefault(t *testing.T) {
+	server, keys := newKeyRecordingServer(0)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+	service.Charge.Refund("ch_fa990a4c10672a93053a774730b0a", "reason")
+	if (*keys)[0] != "" {
+		t.Errorf("Idempotency-Key should be empty, but %s", (*keys)[0])
+	}
+}
+
+func TestAutoIdempotencyKey(t *testing.T) {
+	server, keys := newKeyRecordingServer(1)
+	defer server.Close()
+	service := New("api-key", nil, Config{
+		APIBase:            server.URL,
+		MaxRetries:         2,
+		RetryInitialDelay:  time.Millisecond,
+		AutoIdempotencyKey: true,
+	})
+	service.Charge.Capture("ch_fa990a4c10672a93053a774730b0a")
+	service.Charge.Capture("ch_fa990a4c10672a93053a774730b0a")
+	service.Charge.Retrieve("ch_fa990a4c10672a93053a774730b0a")
+	if len(*keys) != 4 {
+		t.Fatalf("request count should be 4, but %d", len(*keys))
+	}
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if !pattern.MatchString((*keys)[0]) {
+		t.Errorf("Idempotency-Key should be UUID, but %s", (*keys)[0])
+	}
+	if (*keys)[0] != (*keys)[1] {
+		t.Errorf("retried request should reuse Idempotency-Key: %s, %s", (*keys)[0], (*keys)[1])
+	}
+	if (*keys)[1] == (*keys)[2] {
+		t.Errorf("each call should have own Idempotency-Key, but %s", (*keys)[2])
+	}
+	if (*keys)[3] != "" {
+		t.Errorf("GET request should not have Idempotency-Key, but %s", (*keys)[3])
+	}
+}
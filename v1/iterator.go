@@ -0,0 +1,138 @@
+package payjp
+
+import "context"
+
+// defaultIterPageSize はListCallerでLimitが指定されていない場合に、イテレータが1回に取得する件数です。
+const defaultIterPageSize = 100
+
+// pageFetcher はoffsetとlimitを指定して1ページ分の要素を取得する関数です。
+type pageFetcher func(ctx context.Context, offset, limit int) ([]interface{}, bool, error)
+
+// listIter は各リソースのイテレータに共通の、ページを順に取得する処理を実装します。
+type listIter struct {
+	ctx      context.Context
+	fetch    pageFetcher
+	pageSize int
+	offset   int
+	max      int
+
+	page    []interface{}
+	index   int
+	count   int
+	hasMore bool
+	fetched bool
+	current interface{}
+	err     error
+}
+
+func newListIter(ctx context.Context, offset, pageSize int, fetch pageFetcher) listIter {
+	if pageSize <= 0 {
+		pageSize = defaultIterPageSize
+	}
+	return listIter{
+		ctx:      ctx,
+		fetch:    fetch,
+		pageSize: pageSize,
+		offset:   offset,
+	}
+}
+
+// Next は次の要素に進みます。要素がなくなるか、エラーが発生した場合はfalseを返します。
+// 現在のページを読み終えると、次のページを自動的に取得します。
+func (it *listIter) Next() bool {
+	it.current = nil
+	if it.err != nil || (it.max > 0 && it.count >= it.max) {
+		return false
+	}
+	for it.index >= len(it.page) {
+		if it.fetched && !it.hasMore {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		limit := it.pageSize
+		if it.max > 0 && it.max-it.count < limit {
+			limit = it.max - it.count
+		}
+		page, hasMore, err := it.fetch(it.ctx, it.offset, limit)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.fetched = true
+		it.page = page
+		it.index = 0
+		it.hasMore = hasMore
+		it.offset += len(page)
+		if len(page) == 0 {
+			return false
+		}
+	}
+	it.current = it.page[it.index]
+	it.index++
+	it.count++
+	return true
+}
+
+// Err は取得中に発生したエラーを返します。Next()がfalseを返した後に確認してください。
+func (it *listIter) Err() error {
+	return it.err
+}
+
+// windowFetcher はuntil、offset、limitを指定して1ページ分の要素を取得する関数です。
+type windowFetcher func(ctx context.Context, until, offset, limit int) ([]interface{}, bool, error)
+
+// windowPages は作成日時(created)の時間窓を過去へ移動しながらページを取得するpageFetcherを返します。
+//
+// リストは新しい順に並んでいるため、ページの最も古い要素の作成日時をuntilに指定して次のページを取得します。
+// offsetによるページ送りと異なり、取得中に新しい要素が作成されても位置がずれません。
+// 同じ秒に作成された要素が境界をまたぐ場合に備えて窓は1秒重ねて移動し、重複はIDで取り除きます。
+// 1ページ全体が同じ秒の要素で窓が進まない場合は、同じ窓の中でoffsetを進めます。
+func windowPages(until int, fetch windowFetcher, key func(interface{}) (string, int)) pageFetcher {
+	seen := make(map[string]int)
+	offset := 0
+	return func(ctx context.Context, _, limit int) ([]interface{}, bool, error) {
+		for {
+			page, hasMore, err := fetch(ctx, until, offset, limit)
+			if err != nil {
+				return nil, false, err
+			}
+			var items []interface{}
+			oldest := -1
+			for _, item := range page {
+				id, created := key(item)
+				if oldest < 0 || created < oldest {
+					oldest = created
+				}
+				if _, ok := seen[id]; ok {
+					continue
+				}
+				seen[id] = created
+				items = append(items, item)
+			}
+			if !hasMore || len(page) == 0 {
+				return items, false, nil
+			}
+			if next := oldest + 1; until != 0 && next >= until {
+				offset += len(page)
+			} else {
+				until = next
+				offset = 0
+				// 次の窓以降に現れない要素のIDは不要なので捨てる
+				for id, created := range seen {
+					if created > until {
+						delete(seen, id)
+					}
+				}
+			}
+			if len(items) > 0 {
+				return items, true, nil
+			}
+			if err := ctx.Err(); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package payjp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newPagingServer はtotal件の支払いをoffset/limitに従って返すサーバーです。
+// failAtが正の場合、そのoffsetのリクエストでエラーを返します。
+func newPagingServer(total, failAt int) (*httptest.Server, *[]string) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if limit == 0 {
+			limit = 10
+		}
+		if failAt > 0 && offset == failAt {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write(chargeErrorResponseJSON)
+			return
+		}
+		var data []string
+		for i := offset; i < total && i < offset+limit; i++ {
+			data = append(data, fmt.Sprintf(`{"object": "charge", "id": "ch_%d", "amount": %d, "created": %d}`, i, 1000+i, 1500000000-i))
+		}
+		fmt.Fprintf(w, `{"count": %d, "data": [%s], "has_more": %v, "object": "list", "url": "/v1/charges"}`,
+			len(data), strings.Join(data, ","), offset+limit < total)
+	}))
+	return server, &queries
+}
+
+func TestIterAllPages(t *testing.T) {
+	server, queries := newPagingServer(25, 0)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+
+	iter := service.Charge.List().Limit(10).Iter()
+	var ids []string
+	for iter.Next() {
+		ids = append(ids, iter.Value().ID)
+	}
+	if err := iter.Err(); err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+	if len(ids) != 25 {
+		t.Errorf("iterator should return 25 charges, but %d", len(ids))
+	}
+	for i, id := range ids {
+		if id != fmt.Sprintf("ch_%d", i) {
+			t.Errorf("ids[%d] should be ch_%d, but %s", i, i, id)
+		}
+	}
+	expected := []string{"limit=10", "limit=10&offset=10", "limit=10&offset=20"}
+	if strings.Join(*queries, " ") != strings.Join(expected, " ") {
+		t.Errorf("queries are wrong: %v", *queries)
+	}
+	if iter.Next() {
+		t.Error("Next should return false after end")
+	}
+	if iter.Value() != nil {
+		t.Error("Value should be nil after end")
+	}
+}
+
+func TestIterDefaultPageSize(t *testing.T) {
+	server, queries := newPagingServer(5, 0)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+
+	iter := service.Charge.List().CustomerID("cus_xxxxx").Offset(2).Iter()
+	count := 0
+	for iter.Next() {
+		count++
+	}
+	if count != 3 {
+		t.Errorf("iterator should return 3 charges, but %d", count)
+	}
+	if (*queries)[0] != "customer=cus_xxxxx&limit=100&offset=2" {
+		t.Errorf("query is wrong: %s", (*queries)[0])
+	}
+}
+
+func TestIterMax(t *testing.T) {
+	server, queries := newPagingServer(100, 0)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+
+	iter := service.Charge.List().Limit(10).Iter().Max(15)
+	count := 0
+	for iter.Next() {
+		count++
+	}
+	if count != 15 {
+		t.Errorf("iterator should stop after 15 charges, but %d", count)
+	}
+	if len(*queries) != 2 || (*queries)[1] != "limit=5&offset=10" {
+		t.Errorf("queries are wrong: %v", *queries)
+	}
+}
+
+func TestIterError(t *testing.T) {
+	server, _ := newPagingServer(30, 10)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+
+	iter := service.Charge.List().Limit(10).Iter()
+	count := 0
+	for iter.Next() {
+		count++
+	}
+	if count != 10 {
+		t.Errorf("iterator should return 10 charges before error, but %d", count)
+	}
+	if !errors.Is(iter.Err(), ErrCardError) {
+		t.Errorf("err should be card error, but %v", iter.Err())
+	}
+}
+
+func TestIterContextCancel(t *testing.T) {
+	server, queries := newPagingServer(30, 0)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	iter := service.Charge.List().Limit(10).IterContext(ctx)
+	count := 0
+	for iter.Next() {
+		count++
+		if count == 5 {
+			cancel()
+		}
+	}
+	if count != 10 {
+		t.Errorf("iterator should stop at page boundary, but %d", count)
+	}
+	if !errors.Is(iter.Err(), context.Canceled) {
+		t.Errorf("err should be context.Canceled, but %v", iter.Err())
+	}
+	if len(*queries) != 1 {
+		t.Errorf("next page should not be requested, but %v", *queries)
+	}
+}
+
+func TestIterEmpty(t *testing.T) {
+	server, _ := newPagingServer(0, 0)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+
+	iter := service.Customer.List().Iter()
+	if iter.Next() {
+		t.Error("Next should return false for empty list")
+	}
+	if iter.Err() != nil {
+		t.Errorf("err should be nil, but %v", iter.Err())
+	}
+}
+
+// newWindowServer はcreatedの新しい順に支払い(または顧客)を返し、untilで絞り込むサーバーです。
+// inclusiveがtrueの場合はuntilと同じ秒に作成された支払いも含めます。
+// 最初のリクエストの後には新しい支払いを先頭に追加し、取得中の作成をまねます。
+func newWindowServer(created []int, inclusive bool) (*httptest.Server, *[]string) {
+	type charge struct {
+		id      string
+		created int
+	}
+	var charges []charge
+	for i, c := range created {
+		charges = append(charges, charge{fmt.Sprintf("ch_%d", i), c})
+	}
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		query := r.URL.Query()
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		until, _ := strconv.Atoi(query.Get("until"))
+		var matched []charge
+		for _, c := range charges {
+			if until == 0 || c.created < until || (inclusive && c.created == until) {
+				matched = append(matched, c)
+			}
+		}
+		object := "charge"
+		if strings.HasSuffix(r.URL.Path, "/customers") {
+			object = "customer"
+		}
+		var data []string
+		for i := offset; i < len(matched) && i < offset+limit; i++ {
+			data = append(data, fmt.Sprintf(`{"object": "%s", "id": "%s", "created": %d}`, object, matched[i].id, matched[i].created))
+		}
+		fmt.Fprintf(w, `{"count": %d, "data": [%s], "has_more": %v, "object": "list", "url": "%s"}`,
+			len(data), strings.Join(data, ","), offset+limit < len(matched), r.URL.Path)
+		if len(queries) == 1 {
+			inserted := []charge{{"ch_new1", created[0] + 2}, {"ch_new2", created[0] + 1}}
+			charges = append(inserted, charges...)
+		}
+	}))
+	return server, &queries
+}
+
+func TestWindowIterSurvivesInserts(t *testing.T) {
+	// 同じ秒に作成された支払いがページサイズより多い区間を含む
+	created := []int{110, 109, 109, 108, 105, 105, 105, 105, 105, 105, 104, 101, 100}
+	for _, inclusive := range []bool{false, true} {
+		server, queries := newWindowServer(created, inclusive)
+		service := New("api-key", nil, Config{APIBase: server.URL})
+
+		iter := service.Charge.List().Limit(3).Offset(5).WindowIter()
+		var ids []string
+		for iter.Next() {
+			ids = append(ids, iter.Value().ID)
+		}
+		server.Close()
+		if err := iter.Err(); err != nil {
+			t.Errorf("err should be nil, but %v", err)
+		}
+		if len(ids) != len(created) {
+			t.Errorf("iterator should return %d charges, but %d: %v", len(created), len(ids), ids)
+			continue
+		}
+		for i, id := range ids {
+			if id != fmt.Sprintf("ch_%d", i) {
+				t.Errorf("ids[%d] should be ch_%d, but %s (inclusive=%v)", i, i, id, inclusive)
+			}
+		}
+		if (*queries)[0] != "limit=3" {
+			t.Errorf("first query should ignore offset, but %s", (*queries)[0])
+		}
+		if (*queries)[1] != "limit=3&until=110" {
+			t.Errorf("second query should start the window after the oldest charge, but %s", (*queries)[1])
+		}
+	}
+}
+
+func TestWindowIterMax(t *testing.T) {
+	server, _ := newWindowServer([]int{105, 104, 103, 102, 101}, false)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+
+	iter := service.Customer.List().Limit(2).Until(time.Unix(104, 0)).WindowIter().Max(2)
+	var ids []string
+	for iter.Next() {
+		ids = append(ids, iter.Value().ID)
+	}
+	if err := iter.Err(); err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+	if strings.Join(ids, ",") != "ch_2,ch_3" {
+		t.Errorf("iterator should stop at max, but %v", ids)
+	}
+}
@@ -0,0 +1,131 @@
+package payjp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// requestIDHeader はPAY.JPがリクエストごとに付与するIDのヘッダーです。
+const requestIDHeader = "X-Request-Id"
+
+// redacted はログから取り除いた値の代わりに出力する文字列です。
+const redacted = "[REDACTED]"
+
+// redactedParams はログに出力しないフォームのパラメータです。
+var redactedParams = map[string]bool{
+	"card[number]": true,
+	"card[cvc]":    true,
+	"number":       true,
+	"cvc":          true,
+}
+
+// LogEntry はPAY.JPへの1回のリクエストのログです。再試行した場合は試行ごとに出力されます。
+//
+// カード番号、CVC、APIキー、Config.RedactMetadataKeysで指定したメタデータの値は"[REDACTED]"に置き換えられています。
+type LogEntry struct {
+	Operation     string        // 論理的な操作名(e.g. "charge.create")
+	Method        string        // HTTPメソッド
+	Path          string        // APIBaseからのリクエストのパス(クエリーを含む)
+	StatusCode    int           // レスポンスのHTTPステータス。通信エラーの場合は0
+	Latency       time.Duration // リクエストの送信からレスポンスヘッダーの受信までの時間
+	RequestID     string        // PAY.JPのリクエストID(X-Request-Idヘッダー)
+	Attempt       int           // 何回目の試行か(1から始まります)
+	RequestHeader http.Header   // リクエストヘッダー
+	RequestBody   string        // フォーム形式のリクエストボディ
+	Err           error         // 通信エラー
+}
+
+// Logger はリクエストのログを出力するインタフェースです。Config.Loggerに指定します。
+type Logger interface {
+	LogRequest(ctx context.Context, entry *LogEntry)
+}
+
+// LoggerFunc は関数をLoggerとして使用するための型です。
+type LoggerFunc func(ctx context.Context, entry *LogEntry)
+
+// LogRequest はf(ctx, entry)を呼びます。
+func (f LoggerFunc) LogRequest(ctx context.Context, entry *LogEntry) {
+	f(ctx, entry)
+}
+
+// NewStdLogger はlog.Loggerにkey=value形式でログを出力するLoggerを返します。loggerがnilの場合は標準のロガーを使用します:
+//
+//     pay := payjp.New("sk_test_xxxxx", nil, payjp.Config{
+//         Logger: payjp.NewStdLogger(nil),
+//     })
+//     // payjp: operation=charge.create method=POST path=/charges status=200 latency=120ms request_id=req_xxxxx attempt=1 body="amount=1000&card%5Bcvc%5D=%5BREDACTED%5D..."
+func NewStdLogger(logger *log.Logger) Logger {
+	return LoggerFunc(func(ctx context.Context, entry *LogEntry) {
+		line := fmt.Sprintf("payjp: operation=%s method=%s path=%s status=%d latency=%s request_id=%s attempt=%d body=%q",
+			entry.Operation, entry.Method, entry.Path, entry.StatusCode, entry.Latency, entry.RequestID, entry.Attempt, entry.RequestBody)
+		if entry.Err != nil {
+			line += fmt.Sprintf(" error=%q", entry.Err.Error())
+		}
+		if logger == nil {
+			log.Print(line)
+		} else {
+			logger.Print(line)
+		}
+	})
+}
+
+// logRequest はリクエストと、そのレスポンスまたはエラーをLoggerに渡します。
+func (s Service) logRequest(ctx context.Context, request *http.Request, payload []byte, resp *http.Response, err error, attempt int, latency time.Duration) {
+	entry := &LogEntry{
+		Method:        request.Method,
+		Path:          strings.TrimPrefix(request.URL.String(), s.apiBase),
+		Latency:       latency,
+		Attempt:       attempt + 1,
+		RequestHeader: redactHeader(request.Header),
+		RequestBody:   s.redactBody(payload),
+		Err:           err,
+	}
+	if call := callFromContext(ctx); call != nil {
+		entry.Operation = call.Operation
+	}
+	if resp != nil {
+		entry.StatusCode = resp.StatusCode
+		entry.RequestID = resp.Header.Get(requestIDHeader)
+	}
+	s.logger.LogRequest(ctx, entry)
+}
+
+func redactHeader(header http.Header) http.Header {
+	result := header.Clone()
+	if result.Get("Authorization") != "" {
+		result.Set("Authorization", redacted)
+	}
+	return result
+}
+
+// redactBody はカード番号、CVC、指定されたメタデータの値を取り除いたリクエストボディを返します。
+func (s Service) redactBody(payload []byte) string {
+	if len(payload) == 0 {
+		return ""
+	}
+	values, err := url.ParseQuery(string(payload))
+	if err != nil {
+		// 解析できないボディはカード情報を含む可能性があるため出力しない
+		return redacted
+	}
+	for key := range values {
+		if redactedParams[key] || s.redactMetadataKey(key) {
+			for i := range values[key] {
+				values[key][i] = redacted
+			}
+		}
+	}
+	return values.Encode()
+}
+
+func (s Service) redactMetadataKey(key string) bool {
+	if !strings.HasPrefix(key, "metadata[") || !strings.HasSuffix(key, "]") {
+		return false
+	}
+	return s.redactMetadataKeys[key[len("metadata["):len(key)-1]]
+}
@@ -0,0 +1,70 @@
+package payjp
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLoggerRedactsCardData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_1234")
+		w.Write(tokenResponseJSON)
+	}))
+	defer server.Close()
+	var entries []*LogEntry
+	service := New("sk_test_secret", nil, Config{
+		APIBase: server.URL,
+		Logger: LoggerFunc(func(ctx context.Context, entry *LogEntry) {
+			entries = append(entries, entry)
+		}),
+		RedactMetadataKeys: []string{"email"},
+	})
+	_, err := service.Token.Create(Card{
+		Number:   String("4242424242424242"),
+		ExpMonth: Int(2),
+		ExpYear:  Int(2099),
+		CVC:      String("123"),
+		Metadata: Metadata{"email": "pay@example.com", "order_id": "1234"},
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("logger should be called once, but %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.Operation != "token.create" || entry.Method != "POST" || entry.Path != "/tokens" || entry.StatusCode != 200 || entry.RequestID != "req_1234" || entry.Attempt != 1 {
+		t.Errorf("entry is wrong: %+v", entry)
+	}
+	expected := "card%5Bcvc%5D=%5BREDACTED%5D&card%5Bexp_month%5D=2&card%5Bexp_year%5D=2099&card%5Bnumber%5D=%5BREDACTED%5D&metadata%5Bemail%5D=%5BREDACTED%5D&metadata%5Border_id%5D=1234"
+	if entry.RequestBody != expected {
+		t.Errorf("body is wrong: %s", entry.RequestBody)
+	}
+	if entry.RequestHeader.Get("Authorization") != "[REDACTED]" {
+		t.Errorf("Authorization should be redacted, but %s", entry.RequestHeader.Get("Authorization"))
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	mock, _ := NewMockClient(200, chargeResponseJSON)
+	buf := &bytes.Buffer{}
+	service := New("sk_test_secret", mock, Config{Logger: NewStdLogger(log.New(buf, "", 0))})
+	_, err := service.Charge.Create(1000, Charge{
+		Card: Card{Number: String("4242424242424242"), CVC: String("123")},
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	line := buf.String()
+	if !strings.Contains(line, "operation=charge.create method=POST path=/charges status=200") {
+		t.Errorf("log is wrong: %s", line)
+	}
+	if strings.Contains(line, "4242424242424242") || strings.Contains(line, "cvc%5D=123") {
+		t.Errorf("log should not contain card data: %s", line)
+	}
+}
@@ -0,0 +1,68 @@
+package payjp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// PAY.JPのメタデータの制限です。
+const (
+	MetadataMaxKeys        = 20  // 設定できるキーの最大数
+	MetadataMaxKeyLength   = 40  // キーの最大文字数
+	MetadataMaxValueLength = 500 // 値の最大文字数
+)
+
+// Metadata は支払いや顧客などに設定するキーと値の組です。
+//
+// 値に空文字列を指定すると、そのキーを削除します:
+//
+//     pay.Customer.Update(id, payjp.Customer{
+//         Metadata: payjp.Metadata{
+//             "order_id": "1234",
+//             "coupon":   "", // couponを削除する
+//         },
+//     })
+//
+// Metadataを含むリクエストは、送信前にValidateで検証されます。
+type Metadata map[string]string
+
+// Validate はメタデータがPAY.JPの制限を満たしているか検証します。
+//
+// キーは1文字以上40文字以下で"["と"]"を含まないこと、値は500文字以下であること、
+// 削除するキー(値が空文字列)を除いて20件以下であることを確認します。
+func (m Metadata) Validate() error {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errors []string
+	count := 0
+	for _, key := range keys {
+		value := m[key]
+		switch {
+		case key == "":
+			errors = append(errors, "key should not be empty.")
+		case utf8.RuneCountInString(key) > MetadataMaxKeyLength:
+			errors = append(errors, fmt.Sprintf("key '%s' should be at most %d characters.", key, MetadataMaxKeyLength))
+		case strings.ContainsAny(key, "[]"):
+			errors = append(errors, fmt.Sprintf("key '%s' should not contain '[' or ']'.", key))
+		}
+		if utf8.RuneCountInString(value) > MetadataMaxValueLength {
+			errors = append(errors, fmt.Sprintf("value of '%s' should be at most %d characters.", key, MetadataMaxValueLength))
+		}
+		if value != "" {
+			count++
+		}
+	}
+	if count > MetadataMaxKeys {
+		errors = append(errors, fmt.Sprintf("should have at most %d keys, but %d.", MetadataMaxKeys, count))
+	}
+	if len(errors) != 0 {
+		return fmt.Errorf("payjp.Metadata parameter error: %s", strings.Join(errors, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package payjp
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMetadataValidate(t *testing.T) {
+	tooMany := Metadata{}
+	for i := 0; i <= MetadataMaxKeys; i++ {
+		tooMany["key"+strconv.Itoa(i)] = "value"
+	}
+	withDeleted := Metadata{"deleted": ""}
+	for i := 0; i < MetadataMaxKeys; i++ {
+		withDeleted["key"+strconv.Itoa(i)] = "value"
+	}
+	cases := []struct {
+		name     string
+		metadata Metadata
+		valid    bool
+	}{
+		{"nil", nil, true},
+		{"valid", Metadata{"order_id": "1234", "注文番号": strings.Repeat("あ", MetadataMaxValueLength)}, true},
+		{"deleted keys are not counted", withDeleted, true},
+		{"too many keys", tooMany, false},
+		{"empty key", Metadata{"": "value"}, false},
+		{"long key", Metadata{strings.Repeat("k", MetadataMaxKeyLength+1): "value"}, false},
+		{"bracket", Metadata{"card[number]": "value"}, false},
+		{"long value", Metadata{"key": strings.Repeat("v", MetadataMaxValueLength+1)}, false},
+	}
+	for _, c := range cases {
+		err := c.metadata.Validate()
+		if c.valid && err != nil {
+			t.Errorf("%s: err should be nil, but %v", c.name, err)
+		} else if !c.valid && err == nil {
+			t.Errorf("%s: err should not be nil", c.name)
+		}
+	}
+}
+
+func TestMetadataValidatedBeforeRequest(t *testing.T) {
+	mock, transport := NewMockClient(200, customerResponseJSON)
+	service := New("api-key", mock)
+	_, err := service.Customer.Update("cus_121673955bd7aa144de5a8f6c262", Customer{
+		Metadata: Metadata{"card[number]": "4242424242424242"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "payjp.Metadata parameter error") {
+		t.Errorf("err should be metadata error, but %v", err)
+	}
+	if transport.URL != "" {
+		t.Errorf("request should not be sent, but %s", transport.URL)
+	}
+}
+
+func TestMetadataDeleteKey(t *testing.T) {
+	server, form := newFormRecordingServer(chargeResponseJSON)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+	_, err := service.Charge.Update("ch_fa990a4c10672a93053a774730b0a", "", Metadata{"coupon": "", "order_id": "1234"})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if form.Encode() != "description=&metadata%5Bcoupon%5D=&metadata%5Border_id%5D=1234" {
+		t.Errorf("form is wrong: %s", form.Encode())
+	}
+}
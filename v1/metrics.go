@@ -0,0 +1,49 @@
+package payjp
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// CallMetrics はMetricsに渡される、1回のAPI呼び出しの計測値です。
+type CallMetrics struct {
+	Operation  string        // 論理的な操作名(e.g. "charge.create")
+	StatusCode int           // 最後のレスポンスのHTTPステータス。リクエストを送信しなかった場合や通信エラーの場合は0
+	Latency    time.Duration // 再試行を含むAPI呼び出し全体の時間
+	Attempts   int           // 送信したリクエストの数(再試行を含む)
+	ErrorType  string        // PAY.JPのエラーのtype(e.g. "card_error")
+	ErrorCode  string        // PAY.JPのエラーのcode(e.g. "card_declined")
+	Err        error         // API呼び出しが返したエラー
+}
+
+// Metrics はAPI呼び出しの計測値を記録するインタフェースです。Config.Metricsに指定します。
+//
+// expvarで公開する実装がexpvarmetricsパッケージにあります。
+type Metrics interface {
+	RecordCall(ctx context.Context, metrics *CallMetrics)
+}
+
+// metricsInterceptor はAPI呼び出しごとにCallMetricsを記録するInterceptorを返します。
+func metricsInterceptor(m Metrics) Interceptor {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) (interface{}, error) {
+			start := time.Now()
+			result, err := next(ctx, call)
+			metrics := &CallMetrics{
+				Operation:  call.Operation,
+				StatusCode: call.StatusCode,
+				Latency:    time.Since(start),
+				Attempts:   call.Attempts,
+				Err:        err,
+			}
+			var payjpError *Error
+			if errors.As(err, &payjpError) {
+				metrics.ErrorType = payjpError.Type
+				metrics.ErrorCode = payjpError.Code
+			}
+			m.RecordCall(ctx, metrics)
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package payjp
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	mock, transport := NewMockClient(503, []byte(`{}`))
+	transport.AddResponse(402, []byte(`{"error": {"code": "card_declined", "message": "Card declined.", "status": 402, "type": "card_error"}}`))
+	var recorded []*CallMetrics
+	service := New("api-key", mock, Config{
+		MaxRetries:        1,
+		RetryInitialDelay: time.Millisecond,
+		Metrics: metricsFunc(func(ctx context.Context, metrics *CallMetrics) {
+			recorded = append(recorded, metrics)
+		}),
+	})
+	_, err := service.Charge.Retrieve("ch_fa990a4c10672a93053a774730b0a")
+	if !IsCardDeclined(err) {
+		t.Fatalf("err should be card_declined, but %v", err)
+	}
+	if len(recorded) != 1 {
+		t.Fatalf("metrics should be recorded once, but %d", len(recorded))
+	}
+	m := recorded[0]
+	if m.Operation != "charge.retrieve" || m.StatusCode != 402 || m.Attempts != 2 || m.Latency <= 0 {
+		t.Errorf("metrics are wrong: %+v", m)
+	}
+	if m.ErrorType != "card_error" || m.ErrorCode != "card_declined" || m.Err != err {
+		t.Errorf("error metrics are wrong: %+v", m)
+	}
+}
+
+type metricsFunc func(ctx context.Context, metrics *CallMetrics)
+
+func (f metricsFunc) RecordCall(ctx context.Context, metrics *CallMetrics) {
+	f(ctx, metrics)
+}
@@ -0,0 +1,81 @@
+package payjp
+
+import (
+	"context"
+	"net/http"
+)
+
+// Call はInterceptorに渡される、1回のAPI呼び出しの情報です。
+//
+// Operation、ResourceIDは呼び出し前に設定されます。
+// Method以降のフィールドはPAY.JPへのリクエストを送信した時点で設定されるため、nextを呼んだ後に参照してください。
+// パラメータの検証エラーなど、リクエストを送信しなかった場合はゼロ値のままです。
+type Call struct {
+	Operation  string // 論理的な操作名(e.g. "charge.create", "customer.list")
+	ResourceID string // 操作の対象となるリソースのID。作成とリストの場合は空文字列(顧客のカードの作成とリストでは顧客ID)
+
+	Method     string      // HTTPメソッド
+	Path       string      // APIBaseからのリクエストのパス(クエリーを含む)
+	StatusCode int         // 最後のレスポンスのHTTPステータス。通信エラーの場合は0
+	Header     http.Header // 最後のレスポンスのヘッダー
+	Attempts   int         // 送信したリクエストの数(再試行を含む)
+}
+
+// RoundTrip は1回のAPI呼び出しを実行し、デコードされた結果を返す関数です。
+//
+// 結果はChargeService.Createなら*ChargeResponse、ChargeListCaller.Doなら[]*ChargeResponseのように、
+// 各メソッドの戻り値と同じ型です。errがnilでない場合、結果は使用しないでください。
+type RoundTrip func(ctx context.Context, call *Call) (interface{}, error)
+
+// Interceptor はすべてのAPI呼び出しの前後に処理を追加するミドルウェアです。
+// nextを呼ぶと次のInterceptor、最後にはAPI呼び出しが実行されます:
+//
+//     pay.Use(func(next payjp.RoundTrip) payjp.RoundTrip {
+//         return func(ctx context.Context, call *payjp.Call) (interface{}, error) {
+//             start := time.Now()
+//             result, err := next(ctx, call)
+//             log.Printf("%s %s %d %v %v", call.Operation, call.ResourceID, call.StatusCode, time.Since(start), err)
+//             return result, err
+//         }
+//     })
+//
+// nextを呼ばずにエラーを返すと、API呼び出しを中断できます。
+type Interceptor func(next RoundTrip) RoundTrip
+
+// Use はInterceptorを追加します。先に追加したものほど外側で実行されます。
+// API呼び出しと並行して呼ばないでください。
+func (s *Service) Use(interceptors ...Interceptor) {
+	s.interceptors = append(s.interceptors, interceptors...)
+}
+
+type callContextKey struct{}
+
+func callFromContext(ctx context.Context) *Call {
+	call, _ := ctx.Value(callContextKey{}).(*Call)
+	return call
+}
+
+// call はInterceptorを通してAPI呼び出しを実行します。
+// fnが送信したリクエストの情報は、requestによってCallに記録されます。
+func (s Service) call(ctx context.Context, operation, resourceID string, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	var roundTrip RoundTrip = func(ctx context.Context, call *Call) (interface{}, error) {
+		return fn(context.WithValue(ctx, callContextKey{}, call))
+	}
+	for i := len(s.interceptors) - 1; i >= 0; i-- {
+		roundTrip = s.interceptors[i](roundTrip)
+	}
+	return roundTrip(ctx, &Call{Operation: operation, ResourceID: resourceID})
+}
+
+// record は送信したリクエストとそのレスポンスをCallに記録します。
+func (c *Call) record(method, resourcePath string, resp *http.Response) {
+	c.Method = method
+	c.Path = resourcePath
+	c.Attempts++
+	c.StatusCode = 0
+	c.Header = nil
+	if resp != nil {
+		c.StatusCode = resp.StatusCode
+		c.Header = resp.Header
+	}
+}
@@ -0,0 +1,108 @@
+package payjp
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func recordingInterceptor(name string, trace *[]string, calls *[]Call, results *[]interface{}) Interceptor {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) (interface{}, error) {
+			*trace = append(*trace, name+":before")
+			result, err := next(ctx, call)
+			*trace = append(*trace, name+":after")
+			if calls != nil {
+				*calls = append(*calls, *call)
+				*results = append(*results, result)
+			}
+			return result, err
+		}
+	}
+}
+
+func TestInterceptor(t *testing.T) {
+	mock, _ := NewMockClient(200, chargeResponseJSON)
+	var trace []string
+	var calls []Call
+	var results []interface{}
+	service := New("api-key", mock, Config{
+		Interceptors: []Interceptor{recordingInterceptor("outer", &trace, nil, nil)},
+	})
+	service.Use(recordingInterceptor("inner", &trace, &calls, &results))
+
+	charge, err := service.Charge.Retrieve("ch_fa990a4c10672a93053a774730b0a")
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	expected := []string{"outer:before", "inner:before", "inner:after", "outer:after"}
+	if !reflect.DeepEqual(trace, expected) {
+		t.Errorf("interceptors should be called in order, but %v", trace)
+	}
+	call := calls[0]
+	if call.Operation != "charge.retrieve" || call.ResourceID != "ch_fa990a4c10672a93053a774730b0a" {
+		t.Errorf("call is wrong: %s %s", call.Operation, call.ResourceID)
+	}
+	if call.Method != "GET" || call.Path != "/charges/ch_fa990a4c10672a93053a774730b0a" || call.StatusCode != 200 || call.Attempts != 1 {
+		t.Errorf("call is wrong: %s %s %d %d", call.Method, call.Path, call.StatusCode, call.Attempts)
+	}
+	if results[0] != charge {
+		t.Errorf("result should be the decoded charge, but %v", results[0])
+	}
+}
+
+func TestInterceptorListAndError(t *testing.T) {
+	mock, transport := NewMockClient(200, chargeListResponseJSON)
+	transport.AddResponse(400, chargeErrorResponseJSON)
+	var trace []string
+	var calls []Call
+	var results []interface{}
+	service := New("api-key", mock)
+	service.Use(recordingInterceptor("recorder", &trace, &calls, &results))
+
+	charges, _, err := service.Charge.List().Limit(10).Do()
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if calls[0].Operation != "charge.list" || calls[0].Path != "/charges?limit=10" {
+		t.Errorf("call is wrong: %s %s", calls[0].Operation, calls[0].Path)
+	}
+	if list, ok := results[0].([]*ChargeResponse); !ok || len(list) != len(charges) {
+		t.Errorf("result should be []*ChargeResponse, but %#v", results[0])
+	}
+
+	err = charges[0].Capture()
+	if calls[1].Operation != "charge.capture" || calls[1].ResourceID != charges[0].ID || calls[1].StatusCode != 400 {
+		t.Errorf("call is wrong: %s %s %d", calls[1].Operation, calls[1].ResourceID, calls[1].StatusCode)
+	}
+	var payjpErr *Error
+	if !errors.As(err, &payjpErr) || payjpErr.Code != "invalid_number" {
+		t.Errorf("err should be *Error, but %v", err)
+	}
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	mock, transport := NewMockClient(200, customerResponseJSON)
+	service := New("api-key", mock)
+	denied := errors.New("denied")
+	service.Use(func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) (interface{}, error) {
+			if call.Operation == "customer.delete" {
+				return nil, denied
+			}
+			return next(ctx, call)
+		}
+	})
+	err := service.Customer.Delete("cus_121673955bd7aa144de5a8f6c262")
+	if err != denied {
+		t.Errorf("err should be denied, but %v", err)
+	}
+	if transport.URL != "" {
+		t.Errorf("request should not be sent, but %s", transport.URL)
+	}
+	customer, err := service.Customer.Retrieve("cus_121673955bd7aa144de5a8f6c262")
+	if err != nil || customer == nil {
+		t.Errorf("other operations should be called, but %v", err)
+	}
+}
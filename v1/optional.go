@@ -0,0 +1,26 @@
+package payjp
+
+// String は文字列のパラメータを指定するためのポインタを返します。
+//
+// Card、Customer、Subscription、Tenantなどのオプショナルなパラメータは、nilの場合は送信されません。
+// ゼロ値を明示的に送りたい場合や、String("")で値を消去したい場合にも使用します:
+//
+//     pay.Customer.Update(id, payjp.Customer{
+//         Email:       payjp.String("pay@example.com"),
+//         Description: payjp.String(""), // 概要を消去する
+//     })
+func String(v string) *string {
+	return &v
+}
+
+// Int は整数のパラメータを指定するためのポインタを返します。
+func Int(v int) *int {
+	return &v
+}
+
+// Bool は真偽値のパラメータを指定するためのポインタを返します。
+//
+// Bool(false)はfalseを明示的に送信し、nilの場合はAPIのデフォルト値が使われます。
+func Bool(v bool) *bool {
+	return &v
+}
@@ -0,0 +1,12 @@
+// Package otelpayjp はOpenTelemetryのtrace.Tracerをpayjp.Tracerとして使用するためのアダプターです。
+//
+// payjpパッケージがOpenTelemetryに依存しないよう、このパッケージは独自のgo.modを持つ別のモジュールです。
+// 使用するには github.com/payjp/payjp-go/v1/otelpayjp を go.mod に追加してください:
+//
+//     pay := payjp.New("sk_test_xxxxx", nil, payjp.Config{
+//         Tracer: otelpayjp.NewTracer(otel.Tracer("github.com/payjp/payjp-go")),
+//     })
+//
+// API呼び出しごとに"payjp.charge.create"のような名前のSpanKindClientのスパンが作成され、
+// payjp.AttributeOperationなどの属性が設定されます。失敗した呼び出しはエラーとして記録されます。
+package otelpayjp
@@ -0,0 +1,22 @@
+module github.com/payjp/payjp-go/v1/otelpayjp
+
+go 1.23.0
+
+require (
+	github.com/payjp/payjp-go v0.0.0-20261016191158-7ed3dae57f7a
+	go.opentelemetry.io/otel v1.38.0
+	go.opentelemetry.io/otel/sdk v1.38.0
+	go.opentelemetry.io/otel/trace v1.38.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/otel/metric v1.38.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+)
+
+// リポジトリ内での開発用。このモジュールを利用する側ではreplaceは無視され、上記のバージョンが使われます
+replace github.com/payjp/payjp-go => ../..
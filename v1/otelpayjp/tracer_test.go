@@ -0,0 +1,83 @@
+package otelpayjp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/payjp/payjp-go/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var chargeErrorResponseJSON = []byte(`
+{
+  "error": {
+    "code": "invalid_number",
+    "message": "Your card number is invalid.",
+    "param": "card[number]",
+    "status": 400,
+    "type": "card_error"
+  }
+}
+`)
+
+func TestTracer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_1234")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(chargeErrorResponseJSON)
+	}))
+	defer server.Close()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	service := payjp.New("api-key", nil, payjp.Config{
+		APIBase: server.URL,
+		Tracer:  NewTracer(provider.Tracer("github.com/payjp/payjp-go")),
+	})
+
+	_, err := service.Charge.CaptureWithContext(context.Background(), "ch_fa990a4c10672a93053a774730b0a")
+	if err == nil {
+		t.Fatal("err should not be nil")
+	}
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("tracer should end one span, but %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "payjp.charge.capture" {
+		t.Errorf("span name is wrong: %s", span.Name())
+	}
+	if span.SpanKind() != trace.SpanKindClient {
+		t.Errorf("span kind should be client, but %v", span.SpanKind())
+	}
+	attributes := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attributes[kv.Key] = kv.Value
+	}
+	expected := map[string]attribute.Value{
+		payjp.AttributeOperation:  attribute.StringValue("charge.capture"),
+		payjp.AttributeResourceID: attribute.StringValue("ch_fa990a4c10672a93053a774730b0a"),
+		payjp.AttributeHTTPMethod: attribute.StringValue("POST"),
+		payjp.AttributeHTTPStatus: attribute.IntValue(400),
+		payjp.AttributeRetryCount: attribute.IntValue(0),
+		payjp.AttributeRequestID:  attribute.StringValue("req_1234"),
+		payjp.AttributeErrorType:  attribute.StringValue("card_error"),
+		payjp.AttributeErrorCode:  attribute.StringValue("invalid_number"),
+	}
+	for key, value := range expected {
+		if attributes[attribute.Key(key)] != value {
+			t.Errorf("attribute %s should be %v, but %v", key, value.Emit(), attributes[attribute.Key(key)].Emit())
+		}
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("span status should be error, but %v", span.Status().Code)
+	}
+	if len(span.Events()) != 1 || span.Events()[0].Name != "exception" {
+		t.Errorf("error should be recorded as event, but %v", span.Events())
+	}
+}
@@ -2,8 +2,27 @@ package payjp
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"unicode/utf8"
+)
+
+// maxErrorBodyLength はError.Bodyに保持するレスポンスボディの最大バイト数です。
+const maxErrorBodyLength = 1024
+
+// 以下はerrors.Isでエラーの種類を判定するための値です。
+//
+//     if errors.Is(err, payjp.ErrCardError) {
+//         // カードに起因するエラー
+//     }
+var (
+	ErrCardError      = errors.New("payjp: card error")            // カードに起因するエラー
+	ErrInvalidRequest = errors.New("payjp: invalid request")       // パラメータの誤りなど不正なリクエスト
+	ErrAuthentication = errors.New("payjp: authentication failed") // APIキーの認証エラー
+	ErrRateLimit      = errors.New("payjp: rate limit exceeded")   // リクエスト数の制限超過
+	ErrServer         = errors.New("payjp: server error")          // PAY.JP側のエラー
+	ErrNetwork        = errors.New("payjp: network error")         // 通信エラー
 )
 
 // Error はPAY.JP固有のエラーを表す構造体です
@@ -13,33 +32,127 @@ type Error struct {
 	Param   string `json:"param"`
 	Status  int    `json:"status"`
 	Type    string `json:"type"`
+
+	Header http.Header `json:"-"` // レスポンスヘッダー
+	Body   string      `json:"-"` // PAY.JPのエラー形式でないレスポンスのボディ(先頭1024バイトまで)
+
+	err error // このエラーの元になったエラー(クライアント側の検証エラーなど)
 }
 
 func (ce Error) Error() string {
 	if ce.Param != "" {
 		return fmt.Sprintf("%d: Type: %s Code: %s Message: %s, Param: %s", ce.Status, ce.Type, ce.Code, ce.Message, ce.Param)
 	}
+	if ce.Body != "" {
+		return fmt.Sprintf("%d: Type: %s Code: %s Message: %s, Body: %s", ce.Status, ce.Type, ce.Code, ce.Message, ce.Body)
+	}
 	return fmt.Sprintf("%d: Type: %s Code: %s Message: %s", ce.Status, ce.Type, ce.Code, ce.Message)
 }
 
+// Is はerrors.IsでErrCardErrorなどとの比較に使用します。
+func (ce Error) Is(target error) bool {
+	switch target {
+	case ErrCardError:
+		return ce.Type == "card_error"
+	case ErrInvalidRequest:
+		switch ce.Type {
+		case "invalid_request_error", "not_allowed_method_error":
+			return true
+		case "":
+			return ce.Status == http.StatusBadRequest || ce.Status == http.StatusNotFound || ce.Status == http.StatusMethodNotAllowed
+		}
+	case ErrAuthentication:
+		return ce.Type == "auth_error" || ce.Status == http.StatusUnauthorized
+	case ErrRateLimit:
+		return ce.Status == http.StatusTooManyRequests
+	case ErrServer:
+		return ce.Type == "server_error" || ce.Status >= 500
+	}
+	return false
+}
+
+// Unwrap はこのエラーの元になったエラーを返します。
+// クライアント側のカード情報の検証で返されたエラーでは、すべての検証エラーを含むcardvalidate.Errorsを返します。
+func (ce Error) Unwrap() error {
+	return ce.err
+}
+
+// NetworkError はPAY.JPとの通信に失敗したことを表すエラーです。
+// errors.Is(err, ErrNetwork)がtrueになります。
+type NetworkError struct {
+	Err error // http.Clientが返したエラー
+}
+
+func (ne *NetworkError) Error() string {
+	return "payjp: network error: " + ne.Err.Error()
+}
+
+// Unwrap は元のエラーを返します。
+func (ne *NetworkError) Unwrap() error {
+	return ne.Err
+}
+
+// Is はerrors.IsでErrNetworkとの比較に使用します。
+func (ne *NetworkError) Is(target error) bool {
+	return target == ErrNetwork
+}
+
+// IsCardDeclined はカード会社によって支払いが拒否された場合にtrueを返します。
+func IsCardDeclined(err error) bool {
+	var payjpError *Error
+	if !errors.As(err, &payjpError) {
+		return false
+	}
+	return payjpError.Type == "card_error" && (payjpError.Code == "card_declined" || payjpError.Code == "card_flagged")
+}
+
+// IsRetryable は時間をおいて再試行することで成功する可能性のあるエラーの場合にtrueを返します。
+// 通信エラー、リクエスト数の制限超過、PAY.JP側のエラーが該当します。
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrNetwork) || errors.Is(err, ErrRateLimit) || errors.Is(err, ErrServer)
+}
+
 type errorResponse struct {
 	Error Error `json:"error"`
 }
 
+// parseErrorResponse はレスポンスボディの"error"プロパティからErrorを生成します。
+// PAY.JPのエラー形式でない場合はnilを返します。
+func parseErrorResponse(resp *http.Response, body []byte) *Error {
+	rawError := errorResponse{}
+	if json.Unmarshal(body, &rawError) != nil || rawError.Error.Status == 0 {
+		return nil
+	}
+	rawError.Error.Header = resp.Header
+	return &rawError.Error
+}
+
+// newStatusError はPAY.JPのエラー形式でないレスポンス(プロキシのHTMLや空のボディなど)から、
+// HTTPステータスを元にErrorを生成します。
+func newStatusError(resp *http.Response, body []byte, message string) *Error {
+	if len(body) > maxErrorBodyLength {
+		end := maxErrorBodyLength
+		for end > 0 && !utf8.RuneStart(body[end]) {
+			end--
+		}
+		body = body[:end]
+	}
+	return &Error{
+		Message: message,
+		Status:  resp.StatusCode,
+		Header:  resp.Header,
+		Body:    string(body),
+	}
+}
+
+// parseResponseError はrespToBodyに加えて、HTTPステータスが成功でも
+// "error"プロパティを持つレスポンスをエラーとして扱います。
 func parseResponseError(resp *http.Response, err error) ([]byte, error) {
 	body, err := respToBody(resp, err)
 	if err != nil {
 		return nil, err
 	}
-	payjpError := &Error{}
-	err = json.Unmarshal(body, payjpError)
-	if err != nil {
-		// ignore JSON parsing error.
-		// Subscription JSON has same name property 'status' but it is string.
-		// it would be error, but it can be omitted.
-		return body, nil
-	}
-	if payjpError.Status != 0 {
+	if payjpError := parseErrorResponse(resp, body); payjpError != nil {
 		return nil, payjpError
 	}
 	return body, nil
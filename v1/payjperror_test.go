@@ -0,0 +1,189 @@
+package payjp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"unicode/utf8"
+)
+
+var cardDeclinedErrorResponseJSON = []byte(`
+{
+  "error": {
+    "code": "card_declined",
+    "message": "Card declined",
+    "status": 402,
+    "type": "card_error"
+  }
+}
+`)
+
+func TestErrorIs(t *testing.T) {
+	cases := []struct {
+		err    Error
+		target error
+	}{
+		{Error{Type: "card_error", Status: 402}, ErrCardError},
+		{Error{Type: "invalid_request_error", Status: 400}, ErrInvalidRequest},
+		{Error{Type: "not_allowed_method_error", Status: 405}, ErrInvalidRequest},
+		{Error{Status: 404}, ErrInvalidRequest},
+		{Error{Type: "auth_error", Status: 401}, ErrAuthentication},
+		{Error{Type: "client_error", Code: "over_capacity", Status: 429}, ErrRateLimit},
+		{Error{Type: "server_error", Status: 500}, ErrServer},
+		{Error{Status: 502}, ErrServer},
+	}
+	targets := []error{ErrCardError, ErrInvalidRequest, ErrAuthentication, ErrRateLimit, ErrServer, ErrNetwork}
+	for _, c := range cases {
+		for _, target := range targets {
+			expected := target == c.target
+			if errors.Is(&c.err, target) != expected {
+				t.Errorf("errors.Is(%v, %v) should be %v", c.err, target, expected)
+			}
+		}
+	}
+}
+
+func TestErrorStatusAndHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_1234")
+		w.WriteHeader(http.StatusPaymentRequired)
+		w.Write(cardDeclinedErrorResponseJSON)
+	}))
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+	_, err := service.Charge.Create(1000, Charge{CardToken: "tok_xxxxx"})
+
+	if !errors.Is(err, ErrCardError) {
+		t.Errorf("err should be card error, but %v", err)
+	}
+	if !IsCardDeclined(err) {
+		t.Errorf("IsCardDeclined should be true: %v", err)
+	}
+	if IsRetryable(err) {
+		t.Errorf("IsRetryable should be false: %v", err)
+	}
+	var payjpError *Error
+	if !errors.As(err, &payjpError) {
+		t.Fatalf("err should be *Error, but %T", err)
+	}
+	if payjpError.Status != http.StatusPaymentRequired {
+		t.Errorf("Status should be 402, but %d", payjpError.Status)
+	}
+	if payjpError.Header.Get("X-Request-Id") != "req_1234" {
+		t.Errorf("Header should be set, but %v", payjpError.Header)
+	}
+}
+
+func TestNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+	_, err := service.Charge.Retrieve("ch_fa990a4c10672a93053a774730b0a")
+
+	if !errors.Is(err, ErrNetwork) {
+		t.Errorf("err should be network error, but %v", err)
+	}
+	if !IsRetryable(err) {
+		t.Errorf("IsRetryable should be true: %v", err)
+	}
+	var networkError *NetworkError
+	if !errors.As(err, &networkError) || networkError.Err == nil {
+		t.Errorf("err should be *NetworkError, but %T", err)
+	}
+}
+
+func TestIsCardDeclined(t *testing.T) {
+	if IsCardDeclined(&Error{Type: "card_error", Code: "invalid_number"}) {
+		t.Error("invalid_number should not be declined")
+	}
+	if IsCardDeclined(errors.New("card_declined")) {
+		t.Error("non PAY.JP error should not be declined")
+	}
+	if !IsCardDeclined(&Error{Type: "card_error", Code: "card_flagged"}) {
+		t.Error("card_flagged should be declined")
+	}
+}
+
+func TestNonJSONErrorResponse(t *testing.T) {
+	mock, _ := NewMockClient(502, []byte("<html><body><h1>502 Bad Gateway</h1></body></html>"))
+	service := New("api-key", mock)
+	charge, err := service.Charge.Retrieve("ch_fa990a4c10672a93053a774730b0a")
+	if charge != nil {
+		t.Errorf("charge should be nil, but %v", charge)
+	}
+	var payjpError *Error
+	if !errors.As(err, &payjpError) {
+		t.Fatalf("err should be *Error, but %v", err)
+	}
+	if payjpError.Status != 502 {
+		t.Errorf("Status should be 502, but %d", payjpError.Status)
+	}
+	if payjpError.Body != "<html><body><h1>502 Bad Gateway</h1></body></html>" {
+		t.Errorf("Body is wrong: %s", payjpError.Body)
+	}
+	if !errors.Is(err, ErrServer) || !IsRetryable(err) {
+		t.Errorf("err should be server error, but %v", err)
+	}
+}
+
+func TestEmptyErrorResponse(t *testing.T) {
+	mock, _ := NewMockClient(401, nil)
+	service := New("api-key", mock)
+	err := service.Plan.Delete("pln_9589006d14aad86aafeceac06b60")
+	if !errors.Is(err, ErrAuthentication) {
+		t.Errorf("err should be authentication error, but %v", err)
+	}
+}
+
+func TestEmptySuccessResponse(t *testing.T) {
+	mock, _ := NewMockClient(200, []byte(""))
+	service := New("api-key", mock)
+	charge, err := service.Charge.Create(1000, Charge{CardToken: "tok_xxxxx"})
+	if err == nil {
+		t.Error("err should not be nil")
+	}
+	if charge != nil {
+		t.Errorf("charge should be nil, but %v", charge)
+	}
+}
+
+func TestErrorBodyTruncated(t *testing.T) {
+	body := make([]byte, 0, 3000)
+	for len(body) < 3000 {
+		body = append(body, "エラー"...)
+	}
+	mock, _ := NewMockClient(503, body)
+	service := New("api-key", mock)
+	_, err := service.Charge.Retrieve("ch_fa990a4c10672a93053a774730b0a")
+	var payjpError *Error
+	if !errors.As(err, &payjpError) {
+		t.Fatalf("err should be *Error, but %v", err)
+	}
+	if len(payjpError.Body) > maxErrorBodyLength {
+		t.Errorf("Body should be truncated, but %d bytes", len(payjpError.Body))
+	}
+	if !utf8.ValidString(payjpError.Body) {
+		t.Error("Body should be valid UTF-8")
+	}
+}
+
+func TestUpdateSubscriptionStatusIsNotError(t *testing.T) {
+	mock, _ := NewMockClient(200, subscriptionResponseJSON)
+	service := New("api-key", mock)
+	subscription, err := service.Subscription.Update("sub_567a1e44562932ec1a7682d746e0", Subscription{})
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	} else if subscription.Status != SubscriptionActive {
+		t.Errorf("Status should be active, but %v", subscription.Status)
+	}
+}
+
+func TestErrorResponseWithSuccessStatus(t *testing.T) {
+	mock, _ := NewMockClient(200, chargeErrorResponseJSON)
+	service := New("api-key", mock)
+	_, err := service.Charge.Refund("ch_fa990a4c10672a93053a774730b0a", "reason")
+	if !errors.Is(err, ErrCardError) {
+		t.Errorf("err should be card error, but %v", err)
+	}
+}
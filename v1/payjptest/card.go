@@ -0,0 +1,229 @@
+package payjptest
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// 特定の場面で拒否されるテスト用カード番号です。
+const (
+	declinedOnTokenNumber  = "4000000000000002"
+	declinedOnChargeNumber = "4000000000080319"
+)
+
+type card struct {
+	Object          string            `json:"object"`
+	ID              string            `json:"id"`
+	Created         int64             `json:"created"`
+	Name            string            `json:"name"`
+	Last4           string            `json:"last4"`
+	ExpMonth        int               `json:"exp_month"`
+	ExpYear         int               `json:"exp_year"`
+	Brand           string            `json:"brand"`
+	CvcCheck        string            `json:"cvc_check"`
+	Fingerprint     string            `json:"fingerprint"`
+	AddressState    string            `json:"address_state"`
+	AddressCity     string            `json:"address_city"`
+	AddressLine1    string            `json:"address_line1"`
+	AddressLine2    string            `json:"address_line2"`
+	Country         string            `json:"country"`
+	AddressZip      string            `json:"address_zip"`
+	AddressZipCheck string            `json:"address_zip_check"`
+	Customer        string            `json:"customer,omitempty"`
+	Metadata        map[string]string `json:"metadata"`
+
+	number string
+	seq    int
+}
+
+func (c *card) createdAt() (int64, int) {
+	return c.Created, c.seq
+}
+
+// copy はトークンや顧客からカードを取り出す時に使う複製を返します。
+func (c *card) copy() *card {
+	result := *c
+	result.Metadata = make(map[string]string)
+	for key, value := range c.Metadata {
+		result.Metadata[key] = value
+	}
+	return &result
+}
+
+type token struct {
+	Object   string `json:"object"`
+	ID       string `json:"id"`
+	Card     *card  `json:"card"`
+	Created  int64  `json:"created"`
+	LiveMode bool   `json:"livemode"`
+	Used     bool   `json:"used"`
+}
+
+func (s *Server) createToken(ids []string, form url.Values) (interface{}, error) {
+	c, err := s.newCard(form)
+	if err != nil {
+		return nil, err
+	}
+	if c.number == declinedOnTokenNumber {
+		return nil, cardError("card_declined", "Card declined.", "card")
+	}
+	t := &token{
+		Object:  "token",
+		ID:      s.newID("tok"),
+		Card:    c,
+		Created: s.now().Unix(),
+	}
+	s.tokens[t.ID] = t
+	return t, nil
+}
+
+func (s *Server) retrieveToken(ids []string, form url.Values) (interface{}, error) {
+	t, ok := s.tokens[ids[0]]
+	if !ok {
+		return nil, noSuch("token", ids[0], "id")
+	}
+	return t, nil
+}
+
+// useToken はトークンを使用済みにして、カードの複製を返します。
+func (s *Server) useToken(id string) (*card, error) {
+	t, ok := s.tokens[id]
+	if !ok {
+		return nil, noSuch("token", id, "card")
+	}
+	if t.Used {
+		return nil, invalidRequest("token_already_used", "Token has already been used.", "card")
+	}
+	t.Used = true
+	c := t.Card.copy()
+	c.seq = s.nextSeq()
+	return c, nil
+}
+
+// cardSource はformのcardに指定されたトークン、またはcard[number]などのカード情報からカードを作成します。
+// どちらも指定されていない場合はnilを返します。
+func (s *Server) cardSource(form url.Values) (*card, error) {
+	if form.Get("card[number]") != "" {
+		return s.newCard(form)
+	}
+	if id := form.Get("card"); id != "" {
+		return s.useToken(id)
+	}
+	return nil, nil
+}
+
+// newCard はformのcard[...]パラメーターを検証してカードを作成します。
+func (s *Server) newCard(form url.Values) (*card, error) {
+	number := form.Get("card[number]")
+	if number == "" {
+		return nil, invalidRequest("missing_param", "Missing parameter: card[number]", "card[number]")
+	}
+	if !luhn(number) || len(number) < 14 || len(number) > 16 {
+		return nil, cardError("invalid_number", "Invalid card number.", "card[number]")
+	}
+	month, err := strconv.Atoi(form.Get("card[exp_month]"))
+	if err != nil || month < 1 || month > 12 {
+		return nil, cardError("invalid_expiry_month", "Invalid expiry month.", "card[exp_month]")
+	}
+	year, err := strconv.Atoi(form.Get("card[exp_year]"))
+	if err != nil || year < 0 {
+		return nil, cardError("invalid_expiry_year", "Invalid expiry year.", "card[exp_year]")
+	}
+	if year < 100 {
+		year += 2000
+	}
+	now := s.now()
+	if year < now.Year() || (year == now.Year() && month < int(now.Month())) {
+		return nil, cardError("expired_card", "Card has expired.", "card[exp_year]")
+	}
+	cvcCheck := "unchecked"
+	if cvc := form.Get("card[cvc]"); cvc != "" {
+		if len(cvc) < 3 || len(cvc) > 4 || !digits(cvc) {
+			return nil, cardError("invalid_cvc", "Invalid CVC.", "card[cvc]")
+		}
+		cvcCheck = "passed"
+	}
+	country := form.Get("card[country]")
+	if country != "" && len(country) != 2 {
+		return nil, cardError("invalid_card_country", "Invalid country code.", "card[country]")
+	}
+	addressZipCheck := "unchecked"
+	if form.Get("card[address_zip]") != "" {
+		addressZipCheck = "passed"
+	}
+	fingerprint := md5.Sum([]byte(number))
+	c := &card{
+		Object:          "card",
+		ID:              s.newID("car"),
+		Created:         now.Unix(),
+		Name:            form.Get("card[name]"),
+		Last4:           number[len(number)-4:],
+		ExpMonth:        month,
+		ExpYear:         year,
+		Brand:           brand(number),
+		CvcCheck:        cvcCheck,
+		Fingerprint:     hex.EncodeToString(fingerprint[:]),
+		AddressState:    form.Get("card[address_state]"),
+		AddressCity:     form.Get("card[address_city]"),
+		AddressLine1:    form.Get("card[address_line1]"),
+		AddressLine2:    form.Get("card[address_line2]"),
+		Country:         country,
+		AddressZip:      form.Get("card[address_zip]"),
+		AddressZipCheck: addressZipCheck,
+		number:          number,
+		seq:             s.nextSeq(),
+	}
+	c.Metadata, err = updateMetadata(nil, form)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func luhn(number string) bool {
+	if !digits(number) {
+		return false
+	}
+	sum := 0
+	for i := 0; i < len(number); i++ {
+		d := int(number[len(number)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+func brand(number string) string {
+	switch {
+	case strings.HasPrefix(number, "4"):
+		return "Visa"
+	case strings.HasPrefix(number, "34"), strings.HasPrefix(number, "37"):
+		return "American Express"
+	case strings.HasPrefix(number, "35"):
+		return "JCB"
+	case strings.HasPrefix(number, "30"), strings.HasPrefix(number, "36"), strings.HasPrefix(number, "38"):
+		return "Diners Club"
+	case strings.HasPrefix(number, "6011"), strings.HasPrefix(number, "65"):
+		return "Discover"
+	case number[0] == '5' || number[0] == '2':
+		return "MasterCard"
+	}
+	return "Unknown"
+}
@@ -0,0 +1,258 @@
+package payjptest
+
+import (
+	"net/url"
+	"time"
+)
+
+// defaultExpiryDays は与信の有効日数の初期値です。
+const defaultExpiryDays = 7
+
+type charge struct {
+	Object         string            `json:"object"`
+	ID             string            `json:"id"`
+	Amount         int               `json:"amount"`
+	AmountRefunded int               `json:"amount_refunded"`
+	Captured       bool              `json:"captured"`
+	CapturedAt     int64             `json:"captured_at"`
+	Card           *card             `json:"card"`
+	Created        int64             `json:"created"`
+	Currency       string            `json:"currency"`
+	Customer       string            `json:"customer"`
+	Description    string            `json:"description"`
+	ExpiredAt      int64             `json:"expired_at"`
+	FailureCode    string            `json:"failure_code"`
+	FailureMessage string            `json:"failure_message"`
+	LiveMode       bool              `json:"livemode"`
+	Paid           bool              `json:"paid"`
+	RefundReason   string            `json:"refund_reason"`
+	Refunded       bool              `json:"refunded"`
+	Subscription   string            `json:"subscription"`
+	Metadata       map[string]string `json:"metadata"`
+	FeeRate        string            `json:"fee_rate"`
+
+	seq int
+}
+
+func (c *charge) createdAt() (int64, int) {
+	return c.Created, c.seq
+}
+
+func (s *Server) createCharge(ids []string, form url.Values) (interface{}, error) {
+	amount, ok, err := formInt(form, "amount", "invalid_amount")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, invalidRequest("missing_param", "Missing parameter: amount", "amount")
+	}
+	if amount < 50 || amount > 9999999 {
+		return nil, invalidRequest("invalid_amount", "Amount should be between 50 and 9,999,999.", "amount")
+	}
+	if currency := form.Get("currency"); currency != "jpy" {
+		return nil, invalidRequest("invalid_currency", "Invalid currency: "+currency, "currency")
+	}
+	capture, err := formBool(form, "capture", true)
+	if err != nil {
+		return nil, err
+	}
+	expiryDays, ok, err := formInt(form, "expiry_days", "invalid_expiry_days")
+	if err != nil {
+		return nil, err
+	}
+	if ok && capture {
+		return nil, invalidRequest("unnecessary_expiry_days", "expiry_days is only available when capture is false.", "expiry_days")
+	}
+	if !ok {
+		expiryDays = defaultExpiryDays
+	} else if expiryDays < 1 || expiryDays > 60 {
+		return nil, invalidRequest("invalid_expiry_days", "expiry_days should be between 1 and 60.", "expiry_days")
+	}
+	metadata, err := updateMetadata(nil, form)
+	if err != nil {
+		return nil, err
+	}
+
+	var source *card
+	customerID := form.Get("customer")
+	if customerID != "" {
+		cus, ok := s.customers[customerID]
+		if !ok {
+			return nil, noSuch("customer", customerID, "customer")
+		}
+		source, err = cus.card(form.Get("card"))
+	} else {
+		source, err = s.cardSource(form)
+		if err == nil && source == nil {
+			err = invalidRequest("missing_param", "Missing parameter: card", "card")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.newCharge(amount, source, customerID, "")
+	if err != nil {
+		return nil, err
+	}
+	c.Description = form.Get("description")
+	c.Metadata = metadata
+	if capture {
+		c.CapturedAt = c.Created
+	} else {
+		c.Captured = false
+		c.ExpiredAt = s.now().Add(time.Duration(expiryDays) * 24 * time.Hour).Unix()
+	}
+	s.charges[c.ID] = c
+	return c, nil
+}
+
+// newCharge はカードで支払いを行い、確定済みの支払いを返します。保存は呼び出し側で行います。
+func (s *Server) newCharge(amount int, source *card, customerID, subscriptionID string) (*charge, error) {
+	if source.number == declinedOnChargeNumber {
+		return nil, cardError("card_declined", "Card declined.", "card")
+	}
+	now := s.now().Unix()
+	return &charge{
+		Object:       "charge",
+		ID:           s.newID("ch"),
+		Amount:       amount,
+		Captured:     true,
+		CapturedAt:   now,
+		Card:         source.copy(),
+		Created:      now,
+		Currency:     "jpy",
+		Customer:     customerID,
+		Paid:         true,
+		Subscription: subscriptionID,
+		Metadata:     map[string]string{},
+		FeeRate:      "3.00",
+		seq:          s.nextSeq(),
+	}, nil
+}
+
+func (s *Server) charge(id string) (*charge, error) {
+	c, ok := s.charges[id]
+	if !ok {
+		return nil, noSuch("charge", id, "id")
+	}
+	return c, nil
+}
+
+func (s *Server) retrieveCharge(ids []string, form url.Values) (interface{}, error) {
+	return s.charge(ids[0])
+}
+
+func (s *Server) updateCharge(ids []string, form url.Values) (interface{}, error) {
+	c, err := s.charge(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := updateMetadata(c.Metadata, form)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := form["description"]; ok {
+		c.Description = form.Get("description")
+	}
+	c.Metadata = metadata
+	return c, nil
+}
+
+func (s *Server) captureCharge(ids []string, form url.Values) (interface{}, error) {
+	c, err := s.charge(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case c.Captured:
+		return nil, invalidRequest("already_captured", "Charge has already been captured.", "")
+	case c.Refunded:
+		return nil, invalidRequest("cant_capture_refunded_charge", "Cannot capture a refunded charge.", "")
+	case s.now().Unix() > c.ExpiredAt:
+		return nil, invalidRequest("charge_expired", "Charge has expired.", "")
+	}
+	amount, ok, err := formInt(form, "amount", "invalid_amount")
+	if err != nil {
+		return nil, err
+	}
+	if ok && (amount < 50 || amount > c.Amount) {
+		return nil, invalidRequest("capture_amount_gt_net", "Capture amount should be between 50 and the authorized amount.", "amount")
+	}
+	if ok {
+		// 与信額との差額は返金扱いになる
+		c.AmountRefunded = c.Amount - amount
+	}
+	c.Captured = true
+	c.CapturedAt = s.now().Unix()
+	c.ExpiredAt = 0
+	return c, nil
+}
+
+func (s *Server) refundCharge(ids []string, form url.Values) (interface{}, error) {
+	c, err := s.charge(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	if c.Refunded {
+		return nil, invalidRequest("already_refunded", "Charge has already been refunded.", "")
+	}
+	refundable := c.Amount - c.AmountRefunded
+	amount, ok, err := formInt(form, "amount", "invalid_refund_amount")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		amount = refundable
+	} else if !c.Captured && amount != refundable {
+		return nil, invalidRequest("invalid_amount_to_not_captured", "Cannot partially refund an uncaptured charge.", "amount")
+	} else if amount < 1 || amount > refundable {
+		return nil, invalidRequest("refund_amount_gt_net", "Refund amount exceeds the refundable amount.", "amount")
+	}
+	reason := form.Get("refund_reason")
+	if len(reason) > 255 {
+		return nil, invalidRequest("invalid_refund_reason", "refund_reason must be at most 255 characters.", "refund_reason")
+	}
+	c.AmountRefunded += amount
+	c.Refunded = c.AmountRefunded == c.Amount
+	c.RefundReason = reason
+	return c, nil
+}
+
+func (s *Server) reauthCharge(ids []string, form url.Values) (interface{}, error) {
+	c, err := s.charge(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case c.Captured:
+		return nil, invalidRequest("already_captured", "Charge has already been captured.", "")
+	case c.Refunded:
+		return nil, invalidRequest("cant_reauth_refunded_charge", "Cannot reauthorize a refunded charge.", "")
+	}
+	expiryDays, ok, err := formInt(form, "expiry_days", "invalid_expiry_days")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		expiryDays = defaultExpiryDays
+	} else if expiryDays < 1 || expiryDays > 60 {
+		return nil, invalidRequest("invalid_expiry_days", "expiry_days should be between 1 and 60.", "expiry_days")
+	}
+	if c.Card.number == declinedOnChargeNumber {
+		return nil, cardError("card_declined", "Card declined.", "card")
+	}
+	c.ExpiredAt = s.now().Add(time.Duration(expiryDays) * 24 * time.Hour).Unix()
+	return c, nil
+}
+
+func (s *Server) listCharges(ids []string, form url.Values) (interface{}, error) {
+	customerID := form.Get("customer")
+	subscriptionID := form.Get("subscription")
+	var items []listed
+	for _, c := range s.charges {
+		if (customerID == "" || c.Customer == customerID) && (subscriptionID == "" || c.Subscription == subscriptionID) {
+			items = append(items, c)
+		}
+	}
+	return paginate("charges", form, items)
+}
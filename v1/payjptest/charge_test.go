@@ -0,0 +1,141 @@
+package payjptest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	payjp "github.com/payjp/payjp-go/v1"
+)
+
+func errorCode(err error) string {
+	var payjpErr *payjp.Error
+	if errors.As(err, &payjpErr) {
+		return payjpErr.Code
+	}
+	return ""
+}
+
+func TestChargeCapture(t *testing.T) {
+	server, service := newService()
+	defer server.Close()
+
+	charge, err := service.Charge.Create(1000, payjp.Charge{
+		CardToken:  newToken(t, service, "4242424242424242"),
+		Capture:    false,
+		ExpireDays: payjp.Int(3),
+		Metadata:   map[string]string{"order": "1"},
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if charge.Captured || !charge.Paid || charge.Metadata["order"] != "1" {
+		t.Errorf("charge should be authorized: %#v", charge)
+	}
+	if days := charge.ExpiredAt.Sub(charge.CreatedAt); days < 72*time.Hour-time.Minute || days > 72*time.Hour+time.Minute {
+		t.Errorf("charge should expire in 3 days, but %v", days)
+	}
+
+	captured, err := service.Charge.Capture(charge.ID, 800)
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if !captured.Captured || captured.AmountRefunded != 200 {
+		t.Errorf("charge should be captured with 200 refunded: %#v", captured)
+	}
+	_, err = service.Charge.Capture(charge.ID)
+	if errorCode(err) != "already_captured" || !errors.Is(err, payjp.ErrInvalidRequest) {
+		t.Errorf("err should be already_captured, but %v", err)
+	}
+}
+
+func TestChargeExpired(t *testing.T) {
+	server, service := newService()
+	defer server.Close()
+
+	charge, err := service.Charge.Create(1000, payjp.Charge{
+		CardToken: newToken(t, service, "4242424242424242"),
+		Capture:   false,
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	server.Now = func() time.Time { return time.Now().Add(8 * 24 * time.Hour) }
+	_, err = service.Charge.Capture(charge.ID)
+	if errorCode(err) != "charge_expired" {
+		t.Errorf("err should be charge_expired, but %v", err)
+	}
+
+	// 再認証すると期限が延長される
+	reauthorized, err := service.Charge.Reauth(charge.ID, 10)
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if !reauthorized.ExpiredAt.After(server.Now()) {
+		t.Errorf("ExpiredAt should be extended, but %v", reauthorized.ExpiredAt)
+	}
+	if _, err := service.Charge.Capture(charge.ID); err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+}
+
+func TestChargeRefund(t *testing.T) {
+	server, service := newService()
+	defer server.Close()
+
+	charge, err := service.Charge.Create(1000, payjp.Charge{
+		CardToken: newToken(t, service, "4242424242424242"),
+		Capture:   true,
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	refunded, err := service.Charge.Refund(charge.ID, "partial", 300)
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if refunded.Refunded || refunded.AmountRefunded != 300 || refunded.RefundReason != "partial" {
+		t.Errorf("charge should be partially refunded: %#v", refunded)
+	}
+	_, err = service.Charge.Refund(charge.ID, "", 800)
+	if errorCode(err) != "refund_amount_gt_net" {
+		t.Errorf("err should be refund_amount_gt_net, but %v", err)
+	}
+	refunded, err = service.Charge.Refund(charge.ID, "")
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if !refunded.Refunded || refunded.AmountRefunded != 1000 {
+		t.Errorf("charge should be fully refunded: %#v", refunded)
+	}
+	_, err = service.Charge.Refund(charge.ID, "")
+	if errorCode(err) != "already_refunded" {
+		t.Errorf("err should be already_refunded, but %v", err)
+	}
+}
+
+func TestChargeErrors(t *testing.T) {
+	server, service := newService()
+	defer server.Close()
+
+	token := newToken(t, service, "4242424242424242")
+	if _, err := service.Charge.Create(1000, payjp.Charge{CardToken: token, Capture: true}); err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	_, err := service.Charge.Create(1000, payjp.Charge{CardToken: token, Capture: true})
+	if errorCode(err) != "token_already_used" {
+		t.Errorf("err should be token_already_used, but %v", err)
+	}
+	_, err = service.Charge.Create(1000, payjp.Charge{CardToken: newToken(t, service, "4000000000080319"), Capture: true})
+	if errorCode(err) != "card_declined" || !payjp.IsCardDeclined(err) {
+		t.Errorf("err should be card_declined, but %v", err)
+	}
+	_, err = service.Charge.Create(1000, payjp.Charge{CardToken: newToken(t, service, "4242424242424242"), Capture: true, ExpireDays: payjp.Int(3)})
+	if errorCode(err) != "unnecessary_expiry_days" {
+		t.Errorf("err should be unnecessary_expiry_days, but %v", err)
+	}
+	_, err = service.Charge.Create(1000, payjp.Charge{CustomerID: "cus_xxxxx"})
+	if errorCode(err) != "invalid_id" {
+		t.Errorf("err should be invalid_id, but %v", err)
+	}
+}
@@ -0,0 +1,316 @@
+package payjptest
+
+import (
+	"net/url"
+	"strconv"
+)
+
+type customer struct {
+	Object      string            `json:"object"`
+	ID          string            `json:"id"`
+	Created     int64             `json:"created"`
+	DefaultCard *string           `json:"default_card"`
+	Description string            `json:"description"`
+	Email       string            `json:"email"`
+	LiveMode    bool              `json:"livemode"`
+	Metadata    map[string]string `json:"metadata"`
+
+	cards []*card // 新しい順
+	seq   int
+}
+
+func (c *customer) createdAt() (int64, int) {
+	return c.Created, c.seq
+}
+
+// card はIDを指定したカードを返します。IDが空の場合はデフォルトカードを返します。
+func (c *customer) card(id string) (*card, error) {
+	if id == "" {
+		if c.DefaultCard == nil {
+			return nil, cardError("missing_card", "Customer does not have a card.", "card")
+		}
+		id = *c.DefaultCard
+	}
+	for _, existing := range c.cards {
+		if existing.ID == id {
+			return existing, nil
+		}
+	}
+	return nil, invalidRequest("dont_has_this_card", "Customer does not have the card: "+id, "card")
+}
+
+// addCard はカードを追加し、デフォルトカードがなければデフォルトに設定します。
+func (c *customer) addCard(added *card) error {
+	for _, existing := range c.cards {
+		if existing.Fingerprint == added.Fingerprint && existing.ExpMonth == added.ExpMonth && existing.ExpYear == added.ExpYear {
+			return cardError("already_have_the_same_card", "Customer already has the same card.", "card")
+		}
+	}
+	added.Customer = c.ID
+	c.cards = append([]*card{added}, c.cards...)
+	if c.DefaultCard == nil {
+		c.DefaultCard = &added.ID
+	}
+	return nil
+}
+
+// customerJSON はカードと定期課金のリストを含めた顧客のレスポンスです。
+type customerJSON struct {
+	*customer
+	Cards         *list `json:"cards"`
+	Subscriptions *list `json:"subscriptions"`
+}
+
+func (s *Server) customerJSON(c *customer) (interface{}, error) {
+	cards := make([]listed, len(c.cards))
+	for i, card := range c.cards {
+		cards[i] = card
+	}
+	cardList, err := paginate("customers/"+c.ID+"/cards", url.Values{"limit": {"100"}}, cards)
+	if err != nil {
+		return nil, err
+	}
+	subscriptionList, err := paginate("customers/"+c.ID+"/subscriptions", url.Values{"limit": {"100"}}, s.subscriptionsOf(c.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &customerJSON{c, cardList, subscriptionList}, nil
+}
+
+func (s *Server) customer(id string) (*customer, error) {
+	c, ok := s.customers[id]
+	if !ok {
+		return nil, noSuch("customer", id, "id")
+	}
+	return c, nil
+}
+
+func (s *Server) createCustomer(ids []string, form url.Values) (interface{}, error) {
+	id := form.Get("id")
+	if id == "" {
+		id = s.newID("cus")
+	} else if _, ok := s.customers[id]; ok {
+		return nil, invalidRequest("already_exist_id", "Customer already exists: "+id, "id")
+	}
+	metadata, err := updateMetadata(nil, form)
+	if err != nil {
+		return nil, err
+	}
+	source, err := s.cardSource(form)
+	if err != nil {
+		return nil, err
+	}
+	c := &customer{
+		Object:      "customer",
+		ID:          id,
+		Created:     s.now().Unix(),
+		Description: form.Get("description"),
+		Email:       form.Get("email"),
+		Metadata:    metadata,
+		seq:         s.nextSeq(),
+	}
+	if source != nil {
+		c.addCard(source)
+	}
+	s.customers[c.ID] = c
+	return s.customerJSON(c)
+}
+
+func (s *Server) retrieveCustomer(ids []string, form url.Values) (interface{}, error) {
+	c, err := s.customer(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	return s.customerJSON(c)
+}
+
+func (s *Server) updateCustomer(ids []string, form url.Values) (interface{}, error) {
+	c, err := s.customer(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := updateMetadata(c.Metadata, form)
+	if err != nil {
+		return nil, err
+	}
+	if id := form.Get("default_card"); id != "" {
+		if _, err := c.card(id); err != nil {
+			return nil, err
+		}
+	}
+	source, err := s.cardSource(form)
+	if err != nil {
+		return nil, err
+	}
+	if source != nil {
+		if err := c.addCard(source); err != nil {
+			return nil, err
+		}
+		c.DefaultCard = &source.ID
+	}
+	if id := form.Get("default_card"); id != "" {
+		c.DefaultCard = &id
+	}
+	if _, ok := form["email"]; ok {
+		c.Email = form.Get("email")
+	}
+	if _, ok := form["description"]; ok {
+		c.Description = form.Get("description")
+	}
+	c.Metadata = metadata
+	return s.customerJSON(c)
+}
+
+func (s *Server) deleteCustomer(ids []string, form url.Values) (interface{}, error) {
+	c, err := s.customer(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	// 顧客の定期課金も削除される
+	for _, sub := range s.subscriptionsOf(c.ID) {
+		delete(s.subscriptions, sub.(*subscription).ID)
+	}
+	delete(s.customers, c.ID)
+	return &deleted{Deleted: true, ID: c.ID}, nil
+}
+
+func (s *Server) listCustomers(ids []string, form url.Values) (interface{}, error) {
+	var items []listed
+	for _, c := range s.customers {
+		items = append(items, c)
+	}
+	result, err := paginate("customers", form, items)
+	if err != nil {
+		return nil, err
+	}
+	data := result.Data.([]listed)
+	rendered := make([]interface{}, len(data))
+	for i, item := range data {
+		if rendered[i], err = s.customerJSON(item.(*customer)); err != nil {
+			return nil, err
+		}
+	}
+	result.Data = rendered
+	return result, nil
+}
+
+func (s *Server) createCard(ids []string, form url.Values) (interface{}, error) {
+	c, err := s.customer(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	source, err := s.cardSource(form)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, invalidRequest("missing_param", "Missing parameter: card", "card")
+	}
+	if err := c.addCard(source); err != nil {
+		return nil, err
+	}
+	if v := form.Get("default"); v == "true" {
+		c.DefaultCard = &source.ID
+	}
+	return source, nil
+}
+
+func (s *Server) customerCard(ids []string) (*customer, *card, error) {
+	c, err := s.customer(ids[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, card := range c.cards {
+		if card.ID == ids[1] {
+			return c, card, nil
+		}
+	}
+	return nil, nil, noSuch("card", ids[1], "id")
+}
+
+func (s *Server) retrieveCard(ids []string, form url.Values) (interface{}, error) {
+	_, card, err := s.customerCard(ids)
+	return card, err
+}
+
+func (s *Server) updateCard(ids []string, form url.Values) (interface{}, error) {
+	_, card, err := s.customerCard(ids)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := updateMetadata(card.Metadata, form)
+	if err != nil {
+		return nil, err
+	}
+	// payjp.Cardの値はcard[...]の形式で送られるため、どちらの形式も受け付ける
+	value := func(key string) (string, bool) {
+		for _, param := range []string{key, "card[" + key + "]"} {
+			if values, ok := form[param]; ok {
+				return values[0], true
+			}
+		}
+		return "", false
+	}
+	for key, field := range map[string]*string{
+		"name":          &card.Name,
+		"address_state": &card.AddressState,
+		"address_city":  &card.AddressCity,
+		"address_line1": &card.AddressLine1,
+		"address_line2": &card.AddressLine2,
+		"address_zip":   &card.AddressZip,
+		"country":       &card.Country,
+	} {
+		if v, ok := value(key); ok {
+			*field = v
+		}
+	}
+	if v, ok := value("exp_month"); ok {
+		month, err := strconv.Atoi(v)
+		if err != nil || month < 1 || month > 12 {
+			return nil, cardError("invalid_expiry_month", "Invalid expiry month.", "exp_month")
+		}
+		card.ExpMonth = month
+	}
+	if v, ok := value("exp_year"); ok {
+		year, err := strconv.Atoi(v)
+		if err != nil || year < s.now().Year() {
+			return nil, cardError("invalid_expiry_year", "Invalid expiry year.", "exp_year")
+		}
+		card.ExpYear = year
+	}
+	card.Metadata = metadata
+	return card, nil
+}
+
+func (s *Server) deleteCard(ids []string, form url.Values) (interface{}, error) {
+	c, card, err := s.customerCard(ids)
+	if err != nil {
+		return nil, err
+	}
+	for i, existing := range c.cards {
+		if existing == card {
+			c.cards = append(c.cards[:i], c.cards[i+1:]...)
+			break
+		}
+	}
+	// デフォルトカードを削除した場合は最も新しいカードをデフォルトにする
+	if c.DefaultCard != nil && *c.DefaultCard == card.ID {
+		c.DefaultCard = nil
+		if len(c.cards) > 0 {
+			c.DefaultCard = &c.cards[0].ID
+		}
+	}
+	return &deleted{Deleted: true, ID: card.ID}, nil
+}
+
+func (s *Server) listCards(ids []string, form url.Values) (interface{}, error) {
+	c, err := s.customer(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	items := make([]listed, len(c.cards))
+	for i, card := range c.cards {
+		items[i] = card
+	}
+	return paginate("customers/"+c.ID+"/cards", form, items)
+}
@@ -0,0 +1,82 @@
+package payjptest
+
+import (
+	"testing"
+
+	payjp "github.com/payjp/payjp-go/v1"
+)
+
+func TestCustomerCards(t *testing.T) {
+	server, service := newService()
+	defer server.Close()
+
+	customer, err := service.Customer.Create(payjp.Customer{
+		ID:        payjp.String("cus_test"),
+		Email:     payjp.String("test@example.com"),
+		CardToken: payjp.String(newToken(t, service, "4242424242424242")),
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if customer.ID != "cus_test" || len(customer.Cards) != 1 || customer.DefaultCard != customer.Cards[0].ID {
+		t.Fatalf("customer should have a default card: %#v", customer)
+	}
+	_, err = service.Customer.Create(payjp.Customer{ID: payjp.String("cus_test")})
+	if errorCode(err) != "already_exist_id" {
+		t.Errorf("err should be already_exist_id, but %v", err)
+	}
+	_, err = service.Customer.AddCardToken(customer.ID, newToken(t, service, "4242424242424242"))
+	if errorCode(err) != "already_have_the_same_card" {
+		t.Errorf("err should be already_have_the_same_card, but %v", err)
+	}
+
+	second, err := service.Customer.AddCardToken(customer.ID, newToken(t, service, "3530111333300000"))
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if second.Brand != "JCB" {
+		t.Errorf("card brand should be JCB, but %s", second.Brand)
+	}
+	updated, err := service.Customer.UpdateCard(customer.ID, second.ID, payjp.Card{Name: payjp.String("PAY HANAKO")})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if updated.Name != "PAY HANAKO" {
+		t.Errorf("card name should be updated, but %s", updated.Name)
+	}
+
+	charge, err := service.Charge.Create(1000, payjp.Charge{CustomerID: customer.ID, Capture: true})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if charge.Card.ID != customer.Cards[0].ID {
+		t.Errorf("charge should use the default card, but %s", charge.Card.ID)
+	}
+
+	// デフォルトカードを削除すると残りのカードがデフォルトになる
+	if err := service.Customer.DeleteCard(customer.ID, customer.Cards[0].ID); err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	customer, err = service.Customer.Retrieve(customer.ID)
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if len(customer.Cards) != 1 || customer.DefaultCard != second.ID {
+		t.Errorf("second card should be the default: %#v", customer)
+	}
+
+	if err := service.Customer.DeleteCard(customer.ID, second.ID); err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	_, err = service.Charge.Create(1000, payjp.Charge{CustomerID: customer.ID, Capture: true})
+	if errorCode(err) != "missing_card" {
+		t.Errorf("err should be missing_card, but %v", err)
+	}
+	if err := service.Customer.Delete(customer.ID); err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	_, err = service.Customer.Retrieve(customer.ID)
+	if errorCode(err) != "invalid_id" {
+		t.Errorf("err should be invalid_id, but %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package payjptest
+
+import (
+	"net/url"
+)
+
+type plan struct {
+	Object     string            `json:"object"`
+	ID         string            `json:"id"`
+	Amount     int               `json:"amount"`
+	BillingDay int               `json:"billing_day"`
+	Created    int64             `json:"created"`
+	Currency   string            `json:"currency"`
+	Interval   string            `json:"interval"`
+	LiveMode   bool              `json:"livemode"`
+	Name       string            `json:"name"`
+	TrialDays  int               `json:"trial_days"`
+	Metadata   map[string]string `json:"metadata"`
+
+	seq int
+}
+
+func (p *plan) createdAt() (int64, int) {
+	return p.Created, p.seq
+}
+
+func (s *Server) plan(id, param string) (*plan, error) {
+	p, ok := s.plans[id]
+	if !ok {
+		return nil, noSuch("plan", id, param)
+	}
+	return p, nil
+}
+
+func (s *Server) createPlan(ids []string, form url.Values) (interface{}, error) {
+	id := form.Get("id")
+	if id == "" {
+		id = s.newID("pln")
+	} else if _, ok := s.plans[id]; ok {
+		return nil, invalidRequest("already_exist_id", "Plan already exists: "+id, "id")
+	}
+	amount, ok, err := formInt(form, "amount", "invalid_plan_amount")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, invalidRequest("missing_param", "Missing parameter: amount", "amount")
+	}
+	if amount < 50 || amount > 9999999 {
+		return nil, invalidRequest("invalid_plan_amount", "Amount should be between 50 and 9,999,999.", "amount")
+	}
+	if currency := form.Get("currency"); currency != "jpy" {
+		return nil, invalidRequest("invalid_currency", "Invalid currency: "+currency, "currency")
+	}
+	interval := form.Get("interval")
+	if interval != "month" && interval != "year" {
+		return nil, invalidRequest("invalid_interval", "Interval should be month or year.", "interval")
+	}
+	trialDays, _, err := formInt(form, "trial_days", "invalid_trial_days")
+	if err != nil {
+		return nil, err
+	}
+	if trialDays < 0 || trialDays > 365 {
+		return nil, invalidRequest("invalid_trial_days", "trial_days should be between 0 and 365.", "trial_days")
+	}
+	billingDay, ok, err := formInt(form, "billing_day", "invalid_billing_day")
+	if err != nil {
+		return nil, err
+	}
+	if ok && interval != "month" {
+		return nil, invalidRequest("billing_day_for_non_monthly_plan", "billing_day is only available for monthly plans.", "billing_day")
+	}
+	if ok && (billingDay < 1 || billingDay > 31) {
+		return nil, invalidRequest("invalid_billing_day", "billing_day should be between 1 and 31.", "billing_day")
+	}
+	metadata, err := updateMetadata(nil, form)
+	if err != nil {
+		return nil, err
+	}
+	p := &plan{
+		Object:     "plan",
+		ID:         id,
+		Amount:     amount,
+		BillingDay: billingDay,
+		Created:    s.now().Unix(),
+		Currency:   "jpy",
+		Interval:   interval,
+		Name:       form.Get("name"),
+		TrialDays:  trialDays,
+		Metadata:   metadata,
+		seq:        s.nextSeq(),
+	}
+	s.plans[p.ID] = p
+	return p, nil
+}
+
+func (s *Server) retrievePlan(ids []string, form url.Values) (interface{}, error) {
+	return s.plan(ids[0], "id")
+}
+
+func (s *Server) updatePlan(ids []string, form url.Values) (interface{}, error) {
+	p, err := s.plan(ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := updateMetadata(p.Metadata, form)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := form["name"]; ok {
+		p.Name = form.Get("name")
+	}
+	p.Metadata = metadata
+	return p, nil
+}
+
+func (s *Server) deletePlan(ids []string, form url.Values) (interface{}, error) {
+	p, err := s.plan(ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	delete(s.plans, p.ID)
+	return &deleted{Deleted: true, ID: p.ID}, nil
+}
+
+func (s *Server) listPlans(ids []string, form url.Values) (interface{}, error) {
+	var items []listed
+	for _, p := range s.plans {
+		items = append(items, p)
+	}
+	return paginate("plans", form, items)
+}
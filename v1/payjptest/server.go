@@ -0,0 +1,349 @@
+// Package payjptest はPAY.JPのAPIをメモリ上で再現するテスト用のHTTPサーバーを提供します。
+//
+// 実際のAPIに接続せずに、payjp.Serviceを使ったコードを端から端まで試すことができます。
+//
+//     server := payjptest.NewServer()
+//     defer server.Close()
+//     service := payjp.New("sk_test_xxxxx", nil, payjp.Config{APIBase: server.URL})
+//
+// トークン、支払い(作成・確定・返金・再認証)、顧客とカード、プラン、
+// 定期課金(停止・再開・キャンセル)に対応しています。
+// 確定済みの支払いの再確定など、状態遷移に反する操作にはPAY.JPと同じ形式のエラーを返します。
+//
+// 次のカード番号で、カードに起因するエラーを再現できます。
+//
+//     4000000000000002  トークン作成時にカードが拒否される(card_declined)
+//     4000000000080319  支払い作成時にカードが拒否される(card_declined)
+package payjptest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server はPAY.JPのAPIを模倣するHTTPサーバーです。
+// URLをpayjp.ConfigのAPIBaseに指定して使います。
+type Server struct {
+	*httptest.Server
+
+	// Now は作成日時や与信の期限の計算に使う現在時刻を返します。nilの場合はtime.Nowを使います。
+	// 与信の期限切れなどを試す場合は、リクエストを送る前に差し替えてください。
+	Now func() time.Time
+
+	mu            sync.Mutex
+	seq           int
+	routes        []route
+	tokens        map[string]*token
+	charges       map[string]*charge
+	customers     map[string]*customer
+	plans         map[string]*plan
+	subscriptions map[string]*subscription
+}
+
+// NewServer はデータが空の状態でサーバーを起動します。使用後はCloseを呼んでください。
+func NewServer() *Server {
+	s := &Server{
+		tokens:        make(map[string]*token),
+		charges:       make(map[string]*charge),
+		customers:     make(map[string]*customer),
+		plans:         make(map[string]*plan),
+		subscriptions: make(map[string]*subscription),
+	}
+	s.routes = []route{
+		{"POST", "tokens", s.createToken},
+		{"GET", "tokens/*", s.retrieveToken},
+		{"POST", "charges", s.createCharge},
+		{"GET", "charges", s.listCharges},
+		{"GET", "charges/*", s.retrieveCharge},
+		{"POST", "charges/*", s.updateCharge},
+		{"POST", "charges/*/capture", s.captureCharge},
+		{"POST", "charges/*/refund", s.refundCharge},
+		{"POST", "charges/*/reauth", s.reauthCharge},
+		{"POST", "customers", s.createCustomer},
+		{"GET", "customers", s.listCustomers},
+		{"GET", "customers/*", s.retrieveCustomer},
+		{"POST", "customers/*", s.updateCustomer},
+		{"DELETE", "customers/*", s.deleteCustomer},
+		{"POST", "customers/*/cards", s.createCard},
+		{"GET", "customers/*/cards", s.listCards},
+		{"GET", "customers/*/cards/*", s.retrieveCard},
+		{"POST", "customers/*/cards/*", s.updateCard},
+		{"DELETE", "customers/*/cards/*", s.deleteCard},
+		{"GET", "customers/*/subscriptions", s.listCustomerSubscriptions},
+		{"GET", "customers/*/subscriptions/*", s.retrieveCustomerSubscription},
+		{"POST", "plans", s.createPlan},
+		{"GET", "plans", s.listPlans},
+		{"GET", "plans/*", s.retrievePlan},
+		{"POST", "plans/*", s.updatePlan},
+		{"DELETE", "plans/*", s.deletePlan},
+		{"POST", "subscriptions", s.createSubscription},
+		{"GET", "subscriptions", s.listSubscriptions},
+		{"GET", "subscriptions/*", s.retrieveSubscription},
+		{"POST", "subscriptions/*", s.updateSubscription},
+		{"DELETE", "subscriptions/*", s.deleteSubscription},
+		{"POST", "subscriptions/*/pause", s.pauseSubscription},
+		{"POST", "subscriptions/*/resume", s.resumeSubscription},
+		{"POST", "subscriptions/*/cancel", s.cancelSubscription},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// route はメソッドとパスのパターンに対応する処理です。パターンの*は任意の1要素に一致します。
+type route struct {
+	method  string
+	pattern string
+	handler func(ids []string, form url.Values) (interface{}, error)
+}
+
+func (r route) match(segments []string) ([]string, bool) {
+	patterns := strings.Split(r.pattern, "/")
+	if len(patterns) != len(segments) {
+		return nil, false
+	}
+	var ids []string
+	for i, pattern := range patterns {
+		if pattern == "*" {
+			ids = append(ids, segments[i])
+		} else if pattern != segments[i] {
+			return nil, false
+		}
+	}
+	return ids, true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, _, ok := r.BasicAuth(); !ok || user == "" {
+		writeError(w, &apiError{http.StatusUnauthorized, "auth_error", "invalid_api_key", "Invalid API Key: Please set your API key.", ""})
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeError(w, invalidRequest("invalid_querystring", "Invalid query string.", ""))
+		return
+	}
+	path := strings.Trim(r.URL.Path, "/")
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "v1"), "/")
+	segments := strings.Split(path, "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	found := false
+	for _, route := range s.routes {
+		ids, ok := route.match(segments)
+		if !ok {
+			continue
+		}
+		found = true
+		if route.method != r.Method {
+			continue
+		}
+		result, err := route.handler(ids, r.Form)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+		return
+	}
+	if found {
+		writeError(w, &apiError{http.StatusMethodNotAllowed, "not_allowed_method_error", "not_allowed_method", "Not allowed method: " + r.Method, ""})
+		return
+	}
+	writeError(w, &apiError{http.StatusNotFound, "invalid_request_error", "not_found", "Unrecognized request URL: " + r.URL.Path, ""})
+}
+
+func (s *Server) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// newID はprefixに続けて29桁の16進数を持つIDを生成します。
+func (s *Server) newID(prefix string) string {
+	b := make([]byte, 15)
+	rand.Read(b)
+	return prefix + "_" + hex.EncodeToString(b)[:29]
+}
+
+// nextSeq は同じ秒に作成されたデータを作成順に並べるための連番を返します。
+func (s *Server) nextSeq() int {
+	s.seq++
+	return s.seq
+}
+
+// apiError はPAY.JPのエラーレスポンスです。
+type apiError struct {
+	status  int
+	Type    string `json:"type"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Param   string `json:"param,omitempty"`
+}
+
+func (e *apiError) Error() string {
+	return e.Message
+}
+
+func invalidRequest(code, message, param string) *apiError {
+	return &apiError{http.StatusBadRequest, "invalid_request_error", code, message, param}
+}
+
+func cardError(code, message, param string) *apiError {
+	return &apiError{http.StatusPaymentRequired, "card_error", code, message, param}
+}
+
+func noSuch(resource, id, param string) *apiError {
+	return &apiError{http.StatusNotFound, "invalid_request_error", "invalid_id", fmt.Sprintf("No such %s: %s", resource, id), param}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	e, ok := err.(*apiError)
+	if !ok {
+		e = &apiError{http.StatusInternalServerError, "server_error", "payjp_wrong", err.Error(), ""}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": struct {
+			*apiError
+			Status int `json:"status"`
+		}{e, e.status},
+	})
+}
+
+// deleted は削除APIのレスポンスです。
+type deleted struct {
+	Deleted  bool   `json:"deleted"`
+	ID       string `json:"id"`
+	LiveMode bool   `json:"livemode"`
+}
+
+// list はリストAPIのレスポンスです。
+type list struct {
+	Count   int         `json:"count"`
+	Data    interface{} `json:"data"`
+	HasMore bool        `json:"has_more"`
+	Object  string      `json:"object"`
+	URL     string      `json:"url"`
+}
+
+// listed はリストで返すデータが実装するインターフェースです。
+type listed interface {
+	createdAt() (int64, int)
+}
+
+// paginate はitemsを新しい順に並べ、limit、offset、since、untilで絞り込んだリストを返します。
+// itemsはスライスで、要素はlistedを実装している必要があります。
+func paginate(path string, form url.Values, items []listed) (*list, error) {
+	limit := 10
+	if v := form.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 100 {
+			return nil, invalidRequest("invalid_querystring", "limit should be between 1 and 100.", "limit")
+		}
+		limit = n
+	}
+	var offset, since, until int64
+	for key, value := range map[string]*int64{"offset": &offset, "since": &since, "until": &until} {
+		if v := form.Get(key); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n < 0 {
+				return nil, invalidRequest("invalid_querystring", "Invalid "+key+".", key)
+			}
+			*value = n
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		ci, si := items[i].createdAt()
+		cj, sj := items[j].createdAt()
+		if ci != cj {
+			return ci > cj
+		}
+		return si > sj
+	})
+	var matched []listed
+	for _, item := range items {
+		created, _ := item.createdAt()
+		if (since == 0 || created >= since) && (until == 0 || created <= until) {
+			matched = append(matched, item)
+		}
+	}
+	data := []listed{}
+	for i := offset; i < int64(len(matched)) && i < offset+int64(limit); i++ {
+		data = append(data, matched[i])
+	}
+	return &list{
+		Count:   len(data),
+		Data:    data,
+		HasMore: offset+int64(limit) < int64(len(matched)),
+		Object:  "list",
+		URL:     "/v1/" + path,
+	}, nil
+}
+
+// formInt はformの整数値を取り出します。値がない場合はokがfalseになります。
+func formInt(form url.Values, key, code string) (value int, ok bool, err error) {
+	v := form.Get(key)
+	if v == "" {
+		return 0, false, nil
+	}
+	value, err = strconv.Atoi(v)
+	if err != nil {
+		return 0, false, invalidRequest(code, fmt.Sprintf("Invalid %s: %s", key, v), key)
+	}
+	return value, true, nil
+}
+
+// formBool はformの真偽値を取り出します。値がない場合はdefaultValueを返します。
+func formBool(form url.Values, key string, defaultValue bool) (bool, error) {
+	switch form.Get(key) {
+	case "":
+		return defaultValue, nil
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return false, invalidRequest("invalid_boolean", fmt.Sprintf("Invalid boolean: %s", form.Get(key)), key)
+}
+
+// updateMetadata はformのmetadata[key]をmetadataに反映します。空文字列を指定したキーは削除します。
+func updateMetadata(metadata map[string]string, form url.Values) (map[string]string, error) {
+	result := make(map[string]string)
+	for key, value := range metadata {
+		result[key] = value
+	}
+	for param := range form {
+		if !strings.HasPrefix(param, "metadata[") || !strings.HasSuffix(param, "]") {
+			continue
+		}
+		key := param[len("metadata[") : len(param)-1]
+		value := form.Get(param)
+		switch {
+		case key == "" || len(key) > 40:
+			return nil, invalidRequest("invalid_metadata_key", "Metadata keys must be 1 to 40 characters.", param)
+		case len(value) > 500:
+			return nil, invalidRequest("invalid_metadata_value", "Metadata values must be at most 500 characters.", param)
+		case value == "":
+			delete(result, key)
+		default:
+			result[key] = value
+		}
+	}
+	if len(result) > 20 {
+		return nil, invalidRequest("too_many_metadata_keys", "Metadata can have at most 20 keys.", "metadata")
+	}
+	return result, nil
+}
@@ -0,0 +1,125 @@
+package payjptest
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	payjp "github.com/payjp/payjp-go/v1"
+)
+
+func newService() (*Server, *payjp.Service) {
+	server := NewServer()
+	return server, payjp.New("sk_test_xxxxx", nil, payjp.Config{APIBase: server.URL})
+}
+
+func newToken(t *testing.T, service *payjp.Service, number string) string {
+	token, err := service.Token.Create(payjp.Card{
+		Number:   payjp.String(number),
+		ExpMonth: payjp.Int(12),
+		ExpYear:  payjp.Int(time.Now().Year() + 1),
+		CVC:      payjp.String("123"),
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	return token.ID
+}
+
+func TestAuthentication(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	service := payjp.New("", nil, payjp.Config{APIBase: server.URL})
+
+	_, err := service.Charge.Retrieve("ch_xxxxx")
+	if !errors.Is(err, payjp.ErrAuthentication) {
+		t.Errorf("err should be ErrAuthentication, but %v", err)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	server, service := newService()
+	defer server.Close()
+
+	_, err := service.Charge.Retrieve("ch_xxxxx")
+	var payjpErr *payjp.Error
+	if !errors.As(err, &payjpErr) {
+		t.Fatalf("err should be *payjp.Error, but %v", err)
+	}
+	if payjpErr.Status != 404 || payjpErr.Code != "invalid_id" || payjpErr.Param != "id" {
+		t.Errorf("error is wrong: %v", payjpErr)
+	}
+	if !errors.Is(err, payjp.ErrInvalidRequest) {
+		t.Errorf("err should be ErrInvalidRequest, but %v", err)
+	}
+}
+
+func TestToken(t *testing.T) {
+	server, service := newService()
+	defer server.Close()
+
+	token, err := service.Token.Create(payjp.Card{
+		Number:   payjp.String("4242424242424242"),
+		ExpMonth: payjp.Int(2),
+		ExpYear:  payjp.Int(time.Now().Year() + 1),
+		Name:     payjp.String("PAY TARO"),
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if token.Card.Last4 != "4242" || token.Card.Brand != "Visa" || token.Card.Name != "PAY TARO" || token.Card.CvcCheck != "unchecked" {
+		t.Errorf("token.Card is wrong: %#v", token.Card)
+	}
+	retrieved, err := service.Token.Retrieve(token.ID)
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if retrieved.Card.ID != token.Card.ID || retrieved.Used {
+		t.Errorf("retrieved token is wrong: %#v", retrieved)
+	}
+
+	cases := []struct {
+		number, code string
+		expYear      int
+	}{
+		{"4242424242424241", "invalid_number", 2099},
+		{"4242424242424242", "expired_card", 2000},
+		{"4000000000000002", "card_declined", 2099},
+	}
+	for _, c := range cases {
+		_, err := service.Token.Create(payjp.Card{Number: payjp.String(c.number), ExpMonth: payjp.Int(1), ExpYear: payjp.Int(c.expYear)})
+		var payjpErr *payjp.Error
+		if !errors.As(err, &payjpErr) || payjpErr.Code != c.code || !errors.Is(err, payjp.ErrCardError) {
+			t.Errorf("%s/%d: error should be %s, but %v", c.number, c.expYear, c.code, err)
+		}
+	}
+}
+
+func TestListPagination(t *testing.T) {
+	server, service := newService()
+	defer server.Close()
+
+	for i := 0; i < 5; i++ {
+		_, err := service.Plan.Create(payjp.Plan{Amount: 500 + i, Currency: "jpy", Interval: "month", ID: "plan_" + strconv.Itoa(i)})
+		if err != nil {
+			t.Fatalf("err should be nil, but %v", err)
+		}
+	}
+	plans, hasMore, err := service.Plan.List().Limit(2).Offset(1).Do()
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if !hasMore || len(plans) != 2 || plans[0].ID != "plan_3" || plans[1].ID != "plan_2" {
+		t.Errorf("list should be newest first: %v %v", hasMore, plans)
+	}
+
+	iter := service.Plan.List().Limit(2).Iter()
+	count := 0
+	for iter.Next() {
+		count++
+	}
+	if iter.Err() != nil || count != 5 {
+		t.Errorf("iterator should return 5 plans, but %d (%v)", count, iter.Err())
+	}
+}
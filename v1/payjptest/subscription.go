@@ -0,0 +1,302 @@
+package payjptest
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type subscription struct {
+	Object             string            `json:"object"`
+	ID                 string            `json:"id"`
+	CanceledAt         int64             `json:"canceled_at"`
+	Created            int64             `json:"created"`
+	CurrentPeriodEnd   int64             `json:"current_period_end"`
+	CurrentPeriodStart int64             `json:"current_period_start"`
+	Customer           string            `json:"customer"`
+	LiveMode           bool              `json:"livemode"`
+	PausedAt           int64             `json:"paused_at"`
+	Plan               *plan             `json:"plan"`
+	NextCyclePlan      *plan             `json:"next_cycle_plan"`
+	Prorate            bool              `json:"prorate"`
+	ResumedAt          int64             `json:"resumed_at"`
+	Start              int64             `json:"start"`
+	Status             string            `json:"status"`
+	TrialEnd           int64             `json:"trial_end"`
+	TrialStart         int64             `json:"trial_start"`
+	Metadata           map[string]string `json:"metadata"`
+
+	seq int
+}
+
+func (s *subscription) createdAt() (int64, int) {
+	return s.Created, s.seq
+}
+
+// subscriptionsOf は顧客の定期課金を返します。
+func (s *Server) subscriptionsOf(customerID string) []listed {
+	items := []listed{}
+	for _, sub := range s.subscriptions {
+		if sub.Customer == customerID {
+			items = append(items, sub)
+		}
+	}
+	return items
+}
+
+func (s *Server) subscription(id string) (*subscription, error) {
+	sub, ok := s.subscriptions[id]
+	if !ok {
+		return nil, noSuch("subscription", id, "id")
+	}
+	return sub, nil
+}
+
+// trialEnd はformのtrial_endを取り出します。"now"の場合はnowがtrueになります。
+func (s *Server) trialEnd(form url.Values) (end int64, now bool, ok bool, err error) {
+	v := form.Get("trial_end")
+	switch v {
+	case "":
+		return 0, false, false, nil
+	case "now":
+		return 0, true, true, nil
+	}
+	end, err = strconv.ParseInt(v, 10, 64)
+	if err != nil || end <= s.now().Unix() {
+		return 0, false, false, invalidRequest("invalid_trial_end", "trial_end should be a future timestamp or 'now'.", "trial_end")
+	}
+	return end, false, true, nil
+}
+
+// startTrial はtrial_endまでのトライアル期間を開始します。
+func (s *Server) startTrial(sub *subscription, end int64) {
+	now := s.now().Unix()
+	sub.Status = "trial"
+	sub.TrialStart = now
+	sub.TrialEnd = end
+	sub.CurrentPeriodStart = now
+	sub.CurrentPeriodEnd = end
+}
+
+// startPeriod は顧客のデフォルトカードでプランの金額を支払い、新しい課金期間を開始します。
+func (s *Server) startPeriod(sub *subscription) error {
+	cus, err := s.customer(sub.Customer)
+	if err != nil {
+		return err
+	}
+	source, err := cus.card("")
+	if err != nil {
+		return err
+	}
+	p := sub.Plan
+	if sub.NextCyclePlan != nil {
+		p = sub.NextCyclePlan
+	}
+	c, err := s.newCharge(p.Amount, source, cus.ID, sub.ID)
+	if err != nil {
+		return err
+	}
+	s.charges[c.ID] = c
+	now := s.now()
+	end := now.AddDate(0, 1, 0)
+	if p.Interval == "year" {
+		end = now.AddDate(1, 0, 0)
+	}
+	sub.Plan, sub.NextCyclePlan = p, nil
+	sub.Status = "active"
+	sub.CurrentPeriodStart = now.Unix()
+	sub.CurrentPeriodEnd = end.Unix()
+	return nil
+}
+
+func (s *Server) createSubscription(ids []string, form url.Values) (interface{}, error) {
+	cus, ok := s.customers[form.Get("customer")]
+	if !ok {
+		return nil, noSuch("customer", form.Get("customer"), "customer")
+	}
+	p, err := s.plan(form.Get("plan"), "plan")
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range s.subscriptionsOf(cus.ID) {
+		if sub := item.(*subscription); sub.Plan.ID == p.ID && sub.Status != "canceled" {
+			return nil, invalidRequest("already_subscribed", "Customer has already subscribed to the plan.", "plan")
+		}
+	}
+	end, skipTrial, ok, err := s.trialEnd(form)
+	if err != nil {
+		return nil, err
+	}
+	prorate, err := formBool(form, "prorate", false)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := updateMetadata(nil, form)
+	if err != nil {
+		return nil, err
+	}
+	planCopy := *p
+	now := s.now()
+	sub := &subscription{
+		Object:   "subscription",
+		ID:       s.newID("sub"),
+		Created:  now.Unix(),
+		Customer: cus.ID,
+		Plan:     &planCopy,
+		Prorate:  prorate,
+		Start:    now.Unix(),
+		Metadata: metadata,
+		seq:      s.nextSeq(),
+	}
+	if !ok && p.TrialDays > 0 {
+		end, ok = now.Add(time.Duration(p.TrialDays)*24*time.Hour).Unix(), true
+	}
+	if ok && !skipTrial {
+		s.startTrial(sub, end)
+	} else if err := s.startPeriod(sub); err != nil {
+		return nil, err
+	}
+	s.subscriptions[sub.ID] = sub
+	return sub, nil
+}
+
+func (s *Server) retrieveSubscription(ids []string, form url.Values) (interface{}, error) {
+	return s.subscription(ids[0])
+}
+
+func (s *Server) retrieveCustomerSubscription(ids []string, form url.Values) (interface{}, error) {
+	if _, err := s.customer(ids[0]); err != nil {
+		return nil, err
+	}
+	sub, err := s.subscription(ids[1])
+	if err != nil || sub.Customer != ids[0] {
+		return nil, noSuch("subscription", ids[1], "id")
+	}
+	return sub, nil
+}
+
+func (s *Server) updateSubscription(ids []string, form url.Values) (interface{}, error) {
+	sub, err := s.subscription(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	if sub.Status == "canceled" {
+		return nil, invalidRequest("already_canceled", "Subscription has already been canceled.", "")
+	}
+	end, skipTrial, hasTrialEnd, err := s.trialEnd(form)
+	if err != nil {
+		return nil, err
+	}
+	prorate, err := formBool(form, "prorate", sub.Prorate)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := updateMetadata(sub.Metadata, form)
+	if err != nil {
+		return nil, err
+	}
+	if id := form.Get("plan"); id != "" {
+		p, err := s.plan(id, "plan")
+		if err != nil {
+			return nil, err
+		}
+		planCopy := *p
+		sub.Plan = &planCopy
+	}
+	if id := form.Get("next_cycle_plan"); id != "" {
+		p, err := s.plan(id, "next_cycle_plan")
+		if err != nil {
+			return nil, err
+		}
+		planCopy := *p
+		sub.NextCyclePlan = &planCopy
+	}
+	if hasTrialEnd && !skipTrial {
+		s.startTrial(sub, end)
+	} else if skipTrial && sub.Status == "trial" {
+		if err := s.startPeriod(sub); err != nil {
+			return nil, err
+		}
+	}
+	sub.Prorate = prorate
+	sub.Metadata = metadata
+	return sub, nil
+}
+
+func (s *Server) pauseSubscription(ids []string, form url.Values) (interface{}, error) {
+	sub, err := s.subscription(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	switch sub.Status {
+	case "paused":
+		return nil, invalidRequest("already_paused", "Subscription has already been paused.", "")
+	case "canceled":
+		return nil, invalidRequest("already_canceled", "Subscription has already been canceled.", "")
+	}
+	sub.Status = "paused"
+	sub.PausedAt = s.now().Unix()
+	return sub, nil
+}
+
+func (s *Server) resumeSubscription(ids []string, form url.Values) (interface{}, error) {
+	sub, err := s.subscription(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	if sub.Status == "active" || sub.Status == "trial" {
+		return nil, invalidRequest("subscription_worked", "Subscription is already working.", "")
+	}
+	end, skipTrial, hasTrialEnd, err := s.trialEnd(form)
+	if err != nil {
+		return nil, err
+	}
+	if hasTrialEnd && !skipTrial {
+		s.startTrial(sub, end)
+	} else if err := s.startPeriod(sub); err != nil {
+		return nil, err
+	}
+	sub.ResumedAt = s.now().Unix()
+	sub.PausedAt = 0
+	sub.CanceledAt = 0
+	return sub, nil
+}
+
+func (s *Server) cancelSubscription(ids []string, form url.Values) (interface{}, error) {
+	sub, err := s.subscription(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	if sub.Status == "canceled" {
+		return nil, invalidRequest("already_canceled", "Subscription has already been canceled.", "")
+	}
+	sub.Status = "canceled"
+	sub.CanceledAt = s.now().Unix()
+	return sub, nil
+}
+
+func (s *Server) deleteSubscription(ids []string, form url.Values) (interface{}, error) {
+	sub, err := s.subscription(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	delete(s.subscriptions, sub.ID)
+	return &deleted{Deleted: true, ID: sub.ID}, nil
+}
+
+func (s *Server) listSubscriptions(ids []string, form url.Values) (interface{}, error) {
+	var items []listed
+	for _, sub := range s.subscriptions {
+		if (form.Get("plan") == "" || sub.Plan.ID == form.Get("plan")) && (form.Get("status") == "" || sub.Status == form.Get("status")) {
+			items = append(items, sub)
+		}
+	}
+	return paginate("subscriptions", form, items)
+}
+
+func (s *Server) listCustomerSubscriptions(ids []string, form url.Values) (interface{}, error) {
+	if _, err := s.customer(ids[0]); err != nil {
+		return nil, err
+	}
+	return paginate("customers/"+ids[0]+"/subscriptions", form, s.subscriptionsOf(ids[0]))
+}
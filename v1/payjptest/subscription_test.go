@@ -0,0 +1,114 @@
+package payjptest
+
+import (
+	"testing"
+
+	payjp "github.com/payjp/payjp-go/v1"
+)
+
+func TestSubscriptionLifecycle(t *testing.T) {
+	server, service := newService()
+	defer server.Close()
+
+	customer, err := service.Customer.Create(payjp.Customer{CardToken: payjp.String(newToken(t, service, "4242424242424242"))})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	plan, err := service.Plan.Create(payjp.Plan{Amount: 500, Currency: "jpy", Interval: "month", TrialDays: 14})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+
+	subscription, err := service.Subscription.Subscribe(customer.ID, payjp.Subscription{PlanID: payjp.String(plan.ID)})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if subscription.Status != payjp.SubscriptionTrial || subscription.Plan.ID != plan.ID {
+		t.Errorf("subscription should be in trial: %#v", subscription)
+	}
+	_, err = service.Subscription.Subscribe(customer.ID, payjp.Subscription{PlanID: payjp.String(plan.ID)})
+	if errorCode(err) != "already_subscribed" {
+		t.Errorf("err should be already_subscribed, but %v", err)
+	}
+
+	// トライアルを終了すると最初の支払いが行われる
+	subscription, err = service.Subscription.Update(subscription.ID, payjp.Subscription{SkipTrial: payjp.Bool(true)})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if subscription.Status != payjp.SubscriptionActive {
+		t.Errorf("subscription should be active, but %v", subscription.Status)
+	}
+	charges, _, err := service.Charge.List().SubscriptionID(subscription.ID).Do()
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if len(charges) != 1 || charges[0].Amount != 500 || charges[0].CustomerID != customer.ID {
+		t.Errorf("subscription should have one charge: %v", charges)
+	}
+
+	transitions := []struct {
+		name   string
+		call   func() (*payjp.SubscriptionResponse, error)
+		status payjp.SubscriptionStatus
+		code   string
+	}{
+		{"resume active", func() (*payjp.SubscriptionResponse, error) {
+			return service.Subscription.Resume(subscription.ID, payjp.Subscription{})
+		}, 0, "subscription_worked"},
+		{"pause", func() (*payjp.SubscriptionResponse, error) { return service.Subscription.Pause(subscription.ID) }, payjp.SubscriptionPaused, ""},
+		{"pause paused", func() (*payjp.SubscriptionResponse, error) { return service.Subscription.Pause(subscription.ID) }, 0, "already_paused"},
+		{"resume", func() (*payjp.SubscriptionResponse, error) {
+			return service.Subscription.Resume(subscription.ID, payjp.Subscription{})
+		}, payjp.SubscriptionActive, ""},
+		{"cancel", func() (*payjp.SubscriptionResponse, error) { return service.Subscription.Cancel(subscription.ID) }, payjp.SubscriptionCanceled, ""},
+		{"cancel canceled", func() (*payjp.SubscriptionResponse, error) { return service.Subscription.Cancel(subscription.ID) }, 0, "already_canceled"},
+	}
+	for _, transition := range transitions {
+		result, err := transition.call()
+		if transition.code != "" {
+			if errorCode(err) != transition.code {
+				t.Errorf("%s: err should be %s, but %v", transition.name, transition.code, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: err should be nil, but %v", transition.name, err)
+		} else if result.Status != transition.status {
+			t.Errorf("%s: status should be %v, but %v", transition.name, transition.status, result.Status)
+		}
+	}
+
+	charges, _, err = service.Charge.List().CustomerID(customer.ID).Do()
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if len(charges) != 2 {
+		t.Errorf("resume should create a charge, but %d charges", len(charges))
+	}
+	if err := service.Subscription.Delete(subscription.ID); err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+	_, err = service.Subscription.Retrieve(customer.ID, subscription.ID)
+	if errorCode(err) != "invalid_id" {
+		t.Errorf("err should be invalid_id, but %v", err)
+	}
+}
+
+func TestSubscriptionWithoutCard(t *testing.T) {
+	server, service := newService()
+	defer server.Close()
+
+	customer, err := service.Customer.Create(payjp.Customer{})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	plan, err := service.Plan.Create(payjp.Plan{Amount: 500, Currency: "jpy", Interval: "month"})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	_, err = service.Subscription.Subscribe(customer.ID, payjp.Subscription{PlanID: payjp.String(plan.ID)})
+	if errorCode(err) != "missing_card" {
+		t.Errorf("err should be missing_card, but %v", err)
+	}
+}
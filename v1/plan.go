@@ -1,10 +1,10 @@
 package payjp
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
-	"strconv"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -26,14 +26,14 @@ func newPlanService(service *Service) *PlanService {
 
 // Plan はプランの作成時に使用する構造体です。
 type Plan struct {
-	Amount     int               // 必須: 金額。50~9,999,999の整数
-	Currency   string            // 3文字のISOコード(現状 “jpy” のみサポート)
-	Interval   string            // month のみ指定可能
-	ID         string            // プランID
-	Name       string            // プランの名前
-	TrialDays  int               // トライアル日数
-	BillingDay int               // 支払いの実行日(1〜31)
-	Metadata   map[string]string // メタデータ
+	Amount     int      `form:"amount"`                // 必須: 金額。50~9,999,999の整数
+	Currency   string   `form:"currency"`              // 3文字のISOコード(現状 “jpy” のみサポート)
+	Interval   string   `form:"interval"`              // month のみ指定可能
+	ID         string   `form:"id,omitempty"`          // プランID
+	Name       string   `form:"name,omitempty"`        // プランの名前
+	TrialDays  int      `form:"trial_days,omitempty"`  // トライアル日数
+	BillingDay int      `form:"billing_day,omitempty"` // 支払いの実行日(1〜31)
+	Metadata   Metadata `form:"metadata"`              // メタデータ
 }
 
 // Create は金額や通貨などを指定して定期購入に利用するプランを生成します。
@@ -42,66 +42,65 @@ type Plan struct {
 //
 // また、支払いの実行日を指定すると、支払い日の固定されたプランを生成することができます。
 func (p PlanService) Create(plan Plan) (*PlanResponse, error) {
-	var errors []string
-	if plan.Amount < 50 || plan.Amount > 9999999 {
-		errors = append(errors, fmt.Sprintf("Amount should be between 50 and 9,999,999, but %d.", plan.Amount))
-	}
-	if plan.Currency == "" {
-		plan.Currency = "jpy"
-	} else if plan.Currency != "jpy" {
-		// todo: if pay.jp supports other currency, fix this condition
-		errors = append(errors, fmt.Sprintf("Only supports 'jpy' as currency, but '%s'.", plan.Currency))
-	}
-	if plan.Interval == "" {
-		plan.Interval = "month"
-	} else if plan.Interval != "month" {
-		// todo: if pay.jp supports other interval options, fix this condition
-		errors = append(errors, fmt.Sprintf("Only supports 'month' as interval, but '%s'.", plan.Interval))
-	}
-	if plan.BillingDay < 0 || plan.BillingDay > 31 {
-		errors = append(errors, fmt.Sprintf("BillingDay should be between 1 and 31, but %d.", plan.BillingDay))
-	}
-	if len(errors) != 0 {
-		return nil, fmt.Errorf("payjp.Plan.Create() parameter error: %s", strings.Join(errors, ", "))
-	}
-	qb := newRequestBuilder()
-	qb.Add("amount", strconv.Itoa(plan.Amount))
-	qb.Add("currency", plan.Currency)
-	qb.Add("interval", plan.Interval)
-	if plan.ID != "" {
-		qb.Add("id", plan.ID)
-	}
-	if plan.Name != "" {
-		qb.Add("name", plan.Name)
-	}
-	if plan.TrialDays != 0 {
-		qb.Add("trial_days", strconv.Itoa(plan.TrialDays))
-	}
-	if plan.BillingDay != 0 {
-		qb.Add("billing_day", strconv.Itoa(plan.BillingDay))
-	}
-	qb.AddMetadata(plan.Metadata)
-	request, err := http.NewRequest("POST", p.service.apiBase+"/plans", qb.Reader())
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	request.Header.Add("Authorization", p.service.apiKey)
+	return p.CreateWithContext(context.Background(), plan)
+}
 
-	body, err := respToBody(p.service.Client.Do(request))
-	if err != nil {
-		return nil, err
-	}
-	return parsePlan(p.service, body, &PlanResponse{})
+// CreateWithContext はcontext.Contextを指定してプランを生成します。
+func (p PlanService) CreateWithContext(ctx context.Context, plan Plan) (*PlanResponse, error) {
+	result, err := p.service.call(ctx, "plan.create", "", func(ctx context.Context) (interface{}, error) {
+		var errors []string
+		if plan.Amount < 50 || plan.Amount > 9999999 {
+			errors = append(errors, fmt.Sprintf("Amount should be between 50 and 9,999,999, but %d.", plan.Amount))
+		}
+		if plan.Currency == "" {
+			plan.Currency = "jpy"
+		} else if plan.Currency != "jpy" {
+			// todo: if pay.jp supports other currency, fix this condition
+			errors = append(errors, fmt.Sprintf("Only supports 'jpy' as currency, but '%s'.", plan.Currency))
+		}
+		if plan.Interval == "" {
+			plan.Interval = "month"
+		} else if plan.Interval != "month" {
+			// todo: if pay.jp supports other interval options, fix this condition
+			errors = append(errors, fmt.Sprintf("Only supports 'month' as interval, but '%s'.", plan.Interval))
+		}
+		if plan.BillingDay < 0 || plan.BillingDay > 31 {
+			errors = append(errors, fmt.Sprintf("BillingDay should be between 1 and 31, but %d.", plan.BillingDay))
+		}
+		if len(errors) != 0 {
+			return nil, fmt.Errorf("payjp.Plan.Create() parameter error: %s", strings.Join(errors, ", "))
+		}
+		values, err := encodeForm(plan)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := respToBody(p.service.request(ctx, "POST", "/plans", formBody(values)))
+		if err != nil {
+			return nil, err
+		}
+		return parsePlan(p.service, body, &PlanResponse{})
+	})
+	response, _ := result.(*PlanResponse)
+	return response, err
 }
 
 // Retrieve plan object. 特定のプラン情報を取得します。
 func (p PlanService) Retrieve(id string) (*PlanResponse, error) {
-	body, err := p.service.retrieve("/plans/" + id)
-	if err != nil {
-		return nil, err
-	}
-	return parsePlan(p.service, body, &PlanResponse{})
+	return p.RetrieveWithContext(context.Background(), id)
+}
+
+// RetrieveWithContext はcontext.Contextを指定して特定のプラン情報を取得します。
+func (p PlanService) RetrieveWithContext(ctx context.Context, id string) (*PlanResponse, error) {
+	result, err := p.service.call(ctx, "plan.retrieve", id, func(ctx context.Context) (interface{}, error) {
+		body, err := p.service.retrieve(ctx, "/plans/"+id)
+		if err != nil {
+			return nil, err
+		}
+		return parsePlan(p.service, body, &PlanResponse{})
+	})
+	response, _ := result.(*PlanResponse)
+	return response, err
 }
 
 func parsePlan(service *Service, body []byte, result *PlanResponse) (*PlanResponse, error) {
@@ -113,31 +112,42 @@ func parsePlan(service *Service, body []byte, result *PlanResponse) (*PlanRespon
 	return result, nil
 }
 
-func (p PlanService) update(id, name string) ([]byte, error) {
-	qb := newRequestBuilder()
-	qb.Add("name", name)
-	request, err := http.NewRequest("POST", p.service.apiBase+"/plans/"+id, qb.Reader())
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	request.Header.Add("Authorization", p.service.apiKey)
+func (p PlanService) update(ctx context.Context, id, name string) ([]byte, error) {
+	values := url.Values{}
+	values.Set("name", name)
 
-	return parseResponseError(p.service.Client.Do(request))
+	return parseResponseError(p.service.request(ctx, "POST", "/plans/"+id, formBody(values)))
 }
 
 // Update はプラン情報を更新します。
 func (p PlanService) Update(id, name string) (*PlanResponse, error) {
-	body, err := p.update(id, name)
-	if err != nil {
-		return nil, err
-	}
-	return parsePlan(p.service, body, &PlanResponse{})
+	return p.UpdateWithContext(context.Background(), id, name)
+}
+
+// UpdateWithContext はcontext.Contextを指定してプラン情報を更新します。
+func (p PlanService) UpdateWithContext(ctx context.Context, id, name string) (*PlanResponse, error) {
+	result, err := p.service.call(ctx, "plan.update", id, func(ctx context.Context) (interface{}, error) {
+		body, err := p.update(ctx, id, name)
+		if err != nil {
+			return nil, err
+		}
+		return parsePlan(p.service, body, &PlanResponse{})
+	})
+	response, _ := result.(*PlanResponse)
+	return response, err
 }
 
 // Delete はプランを削除します。
 func (p PlanService) Delete(id string) error {
-	return p.service.delete("/plans/" + id)
+	return p.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext はcontext.Contextを指定してプランを削除します。
+func (p PlanService) DeleteWithContext(ctx context.Context, id string) error {
+	_, err := p.service.call(ctx, "plan.delete", id, func(ctx context.Context) (interface{}, error) {
+		return nil, p.service.delete(ctx, "/plans/"+id)
+	})
+	return err
 }
 
 // List は生成したプランのリストを取得します。リストは、直近で生成された順番に取得されます。
@@ -182,23 +192,71 @@ func (c *PlanListCaller) Until(until time.Time) *PlanListCaller {
 
 // Do は指定されたクエリーを元にプランのリストを配列で取得します。
 func (c *PlanListCaller) Do() ([]*PlanResponse, bool, error) {
-	body, err := c.service.queryList("/plans", c.limit, c.offset, c.since, c.until)
-	if err != nil {
-		return nil, false, err
-	}
-	raw := &listResponseParser{}
-	err = json.Unmarshal(body, raw)
-	if err != nil {
-		return nil, false, err
-	}
-	result := make([]*PlanResponse, len(raw.Data))
-	for i, rawPlan := range raw.Data {
-		plan := &PlanResponse{}
-		json.Unmarshal(rawPlan, plan)
-		plan.service = c.service
-		result[i] = plan
-	}
-	return result, raw.HasMore, nil
+	return c.DoContext(context.Background())
+}
+
+// DoContext はcontext.Contextを指定してプランのリストを配列で取得します。
+func (c *PlanListCaller) DoContext(ctx context.Context) ([]*PlanResponse, bool, error) {
+	var hasMore bool
+	result, err := c.service.call(ctx, "plan.list", "", func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.queryList(ctx, "/plans", c.limit, c.offset, c.since, c.until)
+		if err != nil {
+			return nil, err
+		}
+		raw := &listResponseParser{}
+		err = json.Unmarshal(body, raw)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]*PlanResponse, len(raw.Data))
+		for i, rawPlan := range raw.Data {
+			plan := &PlanResponse{}
+			json.Unmarshal(rawPlan, plan)
+			plan.service = c.service
+			result[i] = plan
+		}
+		hasMore = raw.HasMore
+		return result, nil
+	})
+	list, _ := result.([]*PlanResponse)
+	return list, hasMore, err
+}
+
+// Iter はプランのリストを順に取得するイテレータを返します。次のページは自動的に取得されます。
+func (c *PlanListCaller) Iter() *PlanIter {
+	return c.IterContext(context.Background())
+}
+
+// IterContext はcontext.Contextを指定してイテレータを返します。ctxがキャンセルされると取得を中断します。
+func (c *PlanListCaller) IterContext(ctx context.Context) *PlanIter {
+	caller := *c
+	return &PlanIter{newListIter(ctx, c.offset, c.limit, func(ctx context.Context, offset, limit int) ([]interface{}, bool, error) {
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	})}
+}
+
+// PlanIter はプランのリストを順に取得するイテレータです。
+type PlanIter struct {
+	listIter
+}
+
+// Max は取得する要素数の上限を設定します。0の場合は上限なしです。
+func (it *PlanIter) Max(n int) *PlanIter {
+	it.max = n
+	return it
+}
+
+// Value は現在の要素を返します。
+func (it *PlanIter) Value() *PlanResponse {
+	value, _ := it.current.(*PlanResponse)
+	return value
 }
 
 // PlanResponse はPlanService.はPlanService.Listで返されるプランを表す構造体です
@@ -233,11 +291,19 @@ type planResponseParser struct {
 
 // Update はプラン情報を更新します。
 func (p *PlanResponse) Update(name string) error {
-	body, err := p.service.Plan.update(p.ID, name)
-	if err != nil {
-		return err
-	}
-	return json.Unmarshal(body, p)
+	return p.UpdateWithContext(context.Background(), name)
+}
+
+// UpdateWithContext はcontext.Contextを指定してプラン情報を更新します。
+func (p *PlanResponse) UpdateWithContext(ctx context.Context, name string) error {
+	_, err := p.service.call(ctx, "plan.update", p.ID, func(ctx context.Context) (interface{}, error) {
+		body, err := p.service.Plan.update(ctx, p.ID, name)
+		if err != nil {
+			return nil, err
+		}
+		return p, json.Unmarshal(body, p)
+	})
+	return err
 }
 
 // Delete はプランを削除します。
@@ -245,6 +311,11 @@ func (p *PlanResponse) Delete() error {
 	return p.service.Plan.Delete(p.ID)
 }
 
+// DeleteWithContext はcontext.Contextを指定してプランを削除します。
+func (p *PlanResponse) DeleteWithContext(ctx context.Context) error {
+	return p.service.Plan.DeleteWithContext(ctx, p.ID)
+}
+
 // UnmarshalJSON はJSONパース用の内部APIです。
 func (p *PlanResponse) UnmarshalJSON(b []byte) error {
 	raw := planResponseParser{}
@@ -0,0 +1,56 @@
+package payjp
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+)
+
+// ResponseMetadata はPAY.JPのレスポンスのステータス、ヘッダー、ボディを保持する構造体です。
+// WithResponseでcontext.Contextに設定すると、API呼び出しの後に値が書き込まれます。
+type ResponseMetadata struct {
+	StatusCode int         // HTTPステータス
+	Header     http.Header // レスポンスヘッダー(RateLimitなどのヘッダーを含む)
+	Body       []byte      // パースする前のレスポンスボディ
+}
+
+// RequestID はPAY.JPのリクエストIDを返します。問い合わせの際に使用してください。
+func (m *ResponseMetadata) RequestID() string {
+	return m.Header.Get(requestIDHeader)
+}
+
+type responseContextKey struct{}
+
+// WithResponse はレスポンスをmetaに書き込むcontext.Contextを返します。
+//
+// 返されたcontext.ContextをRetrieveWithContextなどに渡すと、呼び出しの後にmetaが設定されます。
+// エラーの場合も、PAY.JPからレスポンスを受け取っていれば設定されます:
+//
+//     var meta payjp.ResponseMetadata
+//     charge, err := pay.Charge.RetrieveWithContext(payjp.WithResponse(ctx, &meta), "ch_xxxxx")
+//     log.Println(meta.StatusCode, meta.RequestID())
+//
+// 再試行した場合は最後のレスポンスが、IterContextなど複数のリクエストを送信する場合は最後のリクエストのレスポンスが書き込まれます。
+func WithResponse(ctx context.Context, meta *ResponseMetadata) context.Context {
+	return context.WithValue(ctx, responseContextKey{}, meta)
+}
+
+func responseFromContext(ctx context.Context) *ResponseMetadata {
+	meta, _ := ctx.Value(responseContextKey{}).(*ResponseMetadata)
+	return meta
+}
+
+// recordResponse はレスポンスボディを読み込んでmetaに設定し、読み込んだ内容でボディを置き換えます。
+func recordResponse(meta *ResponseMetadata, resp *http.Response) error {
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return &NetworkError{Err: err}
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	meta.StatusCode = resp.StatusCode
+	meta.Header = resp.Header
+	meta.Body = body
+	return nil
+}
@@ -0,0 +1,49 @@
+package payjp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_1234")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(chargeErrorResponseJSON)
+			return
+		}
+		w.Write(chargeResponseJSON)
+	}))
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+
+	var meta ResponseMetadata
+	charge, err := service.Charge.RetrieveWithContext(WithResponse(context.Background(), &meta), "ch_fa990a4c10672a93053a774730b0a")
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if charge.ID != "ch_fa990a4c10672a93053a774730b0a" {
+		t.Errorf("charge should be parsed, but %s", charge.ID)
+	}
+	if meta.StatusCode != 200 || meta.RequestID() != "req_1234" || meta.Header.Get("X-RateLimit-Remaining") != "99" {
+		t.Errorf("meta is wrong: %d %s %v", meta.StatusCode, meta.RequestID(), meta.Header)
+	}
+	if string(meta.Body) != string(chargeResponseJSON) {
+		t.Errorf("body is wrong: %s", meta.Body)
+	}
+
+	var errMeta ResponseMetadata
+	_, err = service.Charge.CaptureWithContext(WithResponse(context.Background(), &errMeta), "ch_fa990a4c10672a93053a774730b0a")
+	var payjpErr *Error
+	if !errors.As(err, &payjpErr) || payjpErr.Code != "invalid_number" {
+		t.Fatalf("err should be *Error, but %v", err)
+	}
+	if errMeta.StatusCode != 400 || errMeta.RequestID() != "req_1234" || string(errMeta.Body) != string(chargeErrorResponseJSON) {
+		t.Errorf("meta is wrong: %d %s %s", errMeta.StatusCode, errMeta.RequestID(), errMeta.Body)
+	}
+}
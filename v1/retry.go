@@ -0,0 +1,111 @@
+package payjp
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryInitialDelay = 500 * time.Millisecond
+	defaultRetryMaxDelay     = 30 * time.Second
+)
+
+// retryPolicy はConfigで指定された再試行の設定を保持します。
+type retryPolicy struct {
+	maxRetries   int
+	initialDelay time.Duration
+	maxDelay     time.Duration
+}
+
+func newRetryPolicy(config Config) retryPolicy {
+	policy := retryPolicy{
+		maxRetries:   config.MaxRetries,
+		initialDelay: config.RetryInitialDelay,
+		maxDelay:     config.RetryMaxDelay,
+	}
+	if policy.maxRetries < 0 {
+		policy.maxRetries = 0
+	}
+	if policy.initialDelay <= 0 {
+		policy.initialDelay = defaultRetryInitialDelay
+	}
+	if policy.maxDelay <= 0 {
+		policy.maxDelay = defaultRetryMaxDelay
+	}
+	if policy.maxDelay < policy.initialDelay {
+		policy.maxDelay = policy.initialDelay
+	}
+	return policy
+}
+
+// shouldRetry はリクエストを再送してよいかを判定します。
+//
+// 二重決済を防ぐため、再送するのはGET/DELETEなどの冪等なリクエストか、
+// Idempotency-Keyヘッダーが付与されたリクエストのみです。
+func (p retryPolicy) shouldRetry(request *http.Request, resp *http.Response, err error) bool {
+	if request.Context().Err() != nil {
+		return false
+	}
+	if !idempotent(request) {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+func idempotent(request *http.Request) bool {
+	switch request.Method {
+	case "GET", "HEAD", "DELETE":
+		return true
+	}
+	return request.Header.Get("Idempotency-Key") != ""
+}
+
+// delay は再試行までの待ち時間を返します。
+//
+// Retry-Afterヘッダーがあればその値を優先し、なければ指数バックオフにジッターを加えた値を使用します。
+// いずれの場合もmaxDelayを超えることはありません。
+func (p retryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > p.maxDelay {
+				wait = p.maxDelay
+			}
+			return wait
+		}
+	}
+	backoff := p.initialDelay
+	for i := 0; i < attempt && backoff < p.maxDelay; i++ {
+		backoff *= 2
+	}
+	if backoff > p.maxDelay {
+		backoff = p.maxDelay
+	}
+	// equal jitter: [backoff/2, backoff]
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
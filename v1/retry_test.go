@@ -0,0 +1,171 @@
+package payjp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newFlakyServer(failures, status int, header http.Header) (*httptest.Server, *int) {
+	count := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if count <= failures {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write(chargeResponseJSON)
+	}))
+	return server, &count
+}
+
+func TestRetryIdempotentRequest(t *testing.T) {
+	server, count := newFlakyServer(2, http.StatusServiceUnavailable, nil)
+	defer server.Close()
+	service := New("api-key", nil, Config{
+		APIBase:           server.URL,
+		MaxRetries:        3,
+		RetryInitialDelay: time.Millisecond,
+	})
+	charge, err := service.Charge.Retrieve("ch_fa990a4c10672a93053a774730b0a")
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	} else if charge.Amount != 3500 {
+		t.Errorf("charge.Amount should be 3500, but %d.", charge.Amount)
+	}
+	if *count != 3 {
+		t.Errorf("request count should be 3, but %d", *count)
+	}
+}
+
+func TestRetryMaxRetries(t *testing.T) {
+	server, count := newFlakyServer(10, http.StatusInternalServerError, nil)
+	defer server.Close()
+	service := New("api-key", nil, Config{
+		APIBase:           server.URL,
+		MaxRetries:        2,
+		RetryInitialDelay: time.Millisecond,
+	})
+	service.Charge.Retrieve("ch_fa990a4c10672a93053a774730b0a")
+	if *count != 3 {
+		t.Errorf("request count should be 3, but %d", *count)
+	}
+}
+
+func TestRetryDisabledByDefault(t *testing.T) {
+	server, count := newFlakyServer(1, http.StatusServiceUnavailable, nil)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+	service.Charge.Retrieve("ch_fa990a4c10672a93053a774730b0a")
+	if *count != 1 {
+		t.Errorf("request count should be 1, but %d", *count)
+	}
+}
+
+func TestRetryNotIdempotentRequest(t *testing.T) {
+	server, count := newFlakyServer(1, http.StatusServiceUnavailable, nil)
+	defer server.Close()
+	service := New("api-key", nil, Config{
+		APIBase:           server.URL,
+		MaxRetries:        3,
+		RetryInitialDelay: time.Millisecond,
+	})
+	service.Charge.Create(1000, Charge{CardToken: "tok_xxxxx"})
+	if *count != 1 {
+		t.Errorf("POST without Idempotency-Key should not be retried, but sent %d times", *count)
+	}
+}
+
+func TestRetryNotOnClientError(t *testing.T) {
+	server, count := newFlakyServer(1, http.StatusBadRequest, nil)
+	defer server.Close()
+	service := New("api-key", nil, Config{
+		APIBase:           server.URL,
+		MaxRetries:        3,
+		RetryInitialDelay: time.Millisecond,
+	})
+	service.Charge.Retrieve("ch_fa990a4c10672a93053a774730b0a")
+	if *count != 1 {
+		t.Errorf("request count should be 1, but %d", *count)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	server, count := newFlakyServer(1, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}})
+	defer server.Close()
+	service := New("api-key", nil, Config{
+		APIBase:           server.URL,
+		MaxRetries:        1,
+		RetryInitialDelay: time.Millisecond,
+	})
+	start := time.Now()
+	_, err := service.Charge.Retrieve("ch_fa990a4c10672a93053a774730b0a")
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+	if *count != 2 {
+		t.Errorf("request count should be 2, but %d", *count)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Retry-After should be respected, but retried after %v", elapsed)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := newRetryPolicy(Config{
+		RetryInitialDelay: 100 * time.Millisecond,
+		RetryMaxDelay:     time.Second,
+	})
+	cases := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{0, 50 * time.Millisecond, 100 * time.Millisecond},
+		{1, 100 * time.Millisecond, 200 * time.Millisecond},
+		{2, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+	for _, c := range cases {
+		for i := 0; i < 20; i++ {
+			delay := policy.delay(c.attempt, nil)
+			if delay < c.min || delay > c.max {
+				t.Errorf("delay of attempt %d should be between %v and %v, but %v", c.attempt, c.min, c.max, delay)
+			}
+		}
+	}
+}
+
+func TestRetryDelayLongRetryAfter(t *testing.T) {
+	policy := newRetryPolicy(Config{
+		RetryInitialDelay: 100 * time.Millisecond,
+		RetryMaxDelay:     time.Second,
+	})
+	resp := &http.Response{Header: http.Header{"Retry-After": {"86400"}}}
+	if delay := policy.delay(0, resp); delay != time.Second {
+		t.Errorf("delay should be capped at 1s, but %v", delay)
+	}
+	resp.Header.Set("Retry-After", "0")
+	if delay := policy.delay(0, resp); delay != 0 {
+		t.Errorf("delay should be 0, but %v", delay)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if wait, ok := parseRetryAfter("3"); !ok || wait != 3*time.Second {
+		t.Errorf("wait should be 3s, but %v", wait)
+	}
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if wait, ok := parseRetryAfter(date); !ok || wait <= 0 || wait > time.Minute {
+		t.Errorf("wait should be about 1m, but %v", wait)
+	}
+	if _, ok := parseRetryAfter(""); ok {
+		t.Error("empty Retry-After should be ignored")
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("invalid Retry-After should be ignored")
+	}
+}
@@ -0,0 +1,421 @@
+package payjp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// StatementService は取引明細に関するサービスです。
+//
+// 取引明細は売上や手数料などの内訳を集計区間ごとにまとめたもので、CSVファイルとしてダウンロードすることもできます。
+type StatementService struct {
+	service *Service
+}
+
+func newStatementService(service *Service) *StatementService {
+	return &StatementService{
+		service: service,
+	}
+}
+
+// Retrieve は取引明細を取得します。
+func (s StatementService) Retrieve(id string) (*StatementResponse, error) {
+	return s.RetrieveWithContext(context.Background(), id)
+}
+
+// RetrieveWithContext はcontext.Contextを指定して取引明細を取得します。
+func (s StatementService) RetrieveWithContext(ctx context.Context, id string) (*StatementResponse, error) {
+	result, err := s.service.call(ctx, "statement.retrieve", id, func(ctx context.Context) (interface{}, error) {
+		body, err := s.service.retrieve(ctx, "/statements/"+id)
+		if err != nil {
+			return nil, err
+		}
+		result := &StatementResponse{}
+		err = json.Unmarshal(body, result)
+		if err != nil {
+			return nil, err
+		}
+		result.setService(s.service)
+		return result, nil
+	})
+	response, _ := result.(*StatementResponse)
+	return response, err
+}
+
+// CreateStatementURL は取引明細をダウンロードするためのURLを作成します。URLには有効期限があります。
+func (s StatementService) CreateStatementURL(id string) (*StatementURLResponse, error) {
+	return s.CreateStatementURLWithContext(context.Background(), id)
+}
+
+// CreateStatementURLWithContext はcontext.Contextを指定して取引明細のダウンロードURLを作成します。
+func (s StatementService) CreateStatementURLWithContext(ctx context.Context, id string) (*StatementURLResponse, error) {
+	result, err := s.service.call(ctx, "statement.create_statement_url", id, func(ctx context.Context) (interface{}, error) {
+		body, err := respToBody(s.service.request(ctx, "POST", "/statements/"+id+"/statement_urls", nil))
+		if err != nil {
+			return nil, err
+		}
+		result := &StatementURLResponse{}
+		err = json.Unmarshal(body, result)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
+	response, _ := result.(*StatementURLResponse)
+	return response, err
+}
+
+// Download は取引明細のダウンロードURLを作成し、ファイルの内容をwに書き込みます。
+// 戻り値は書き込んだバイト数です。
+//
+//     file, _ := os.Create("statement.csv")
+//     defer file.Close()
+//     _, err := pay.Statement.Download("st_xxxxx", file)
+func (s StatementService) Download(id string, w io.Writer) (int64, error) {
+	return s.DownloadWithContext(context.Background(), id, w)
+}
+
+// DownloadWithContext はcontext.Contextを指定して取引明細のファイルをwに書き込みます。
+func (s StatementService) DownloadWithContext(ctx context.Context, id string, w io.Writer) (int64, error) {
+	statementURL, err := s.CreateStatementURLWithContext(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	// ダウンロードURLは署名付きのため、APIキーは送らない
+	request, err := http.NewRequestWithContext(ctx, "GET", statementURL.URL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := s.service.Client.Do(request)
+	if err != nil {
+		if ctx.Err() == nil {
+			err = &NetworkError{Err: err}
+		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+		return 0, newStatusError(resp, body, http.StatusText(resp.StatusCode))
+	}
+	// wへの書き込みのエラーは通信エラーではないため、ボディの読み込みのエラーのみNetworkErrorにする
+	return io.Copy(w, bodyReader{ctx: ctx, body: resp.Body})
+}
+
+// bodyReader はレスポンスボディの読み込みのエラーをNetworkErrorで返すio.Readerです。
+type bodyReader struct {
+	ctx  context.Context
+	body io.Reader
+}
+
+func (r bodyReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	if err != nil && err != io.EOF && r.ctx.Err() == nil {
+		err = &NetworkError{Err: err}
+	}
+	return n, err
+}
+
+// List は取引明細のリストを取得します。リストは、直近で生成された順番に取得されます。
+func (s StatementService) List() *StatementListCaller {
+	return &StatementListCaller{
+		service: s.service,
+	}
+}
+
+// StatementListCaller は取引明細のリスト取得に使用する構造体です。
+type StatementListCaller struct {
+	service       *Service
+	limit         int
+	offset        int
+	since         int
+	until         int
+	owner         string
+	tenantID      string
+	termID        string
+	statementType string
+}
+
+// Limit はリストの要素数の最大値を設定します(1-100)
+func (c *StatementListCaller) Limit(limit int) *StatementListCaller {
+	c.limit = limit
+	return c
+}
+
+// Offset は取得するリストの先頭要素のインデックスのオフセットを設定します
+func (c *StatementListCaller) Offset(offset int) *StatementListCaller {
+	c.offset = offset
+	return c
+}
+
+// Since はここに指定したタイムスタンプ以降に作成されたデータを取得します
+func (c *StatementListCaller) Since(since time.Time) *StatementListCaller {
+	c.since = int(since.Unix())
+	return c
+}
+
+// Until はここに指定したタイムスタンプ以前に作成されたデータを取得します
+func (c *StatementListCaller) Until(until time.Time) *StatementListCaller {
+	c.until = int(until.Unix())
+	return c
+}
+
+// Owner を指定すると、指定した所有者の取引明細のみを取得します("merchant"または"tenant")
+func (c *StatementListCaller) Owner(owner string) *StatementListCaller {
+	c.owner = owner
+	return c
+}
+
+// TenantID を指定すると、指定したテナントの取引明細のみを取得します(PAY.JP Platformのみ)
+func (c *StatementListCaller) TenantID(id string) *StatementListCaller {
+	c.tenantID = id
+	return c
+}
+
+// TermID を指定すると、指定した集計区間の取引明細のみを取得します
+func (c *StatementListCaller) TermID(id string) *StatementListCaller {
+	c.termID = id
+	return c
+}
+
+// Type を指定すると、指定した種別の取引明細のみを取得します("sales", "service_fee", "forfeit", "transfer_fee", "misc")
+func (c *StatementListCaller) Type(statementType string) *StatementListCaller {
+	c.statementType = statementType
+	return c
+}
+
+// Do は指定されたクエリーを元に取引明細のリストを配列で取得します。
+func (c *StatementListCaller) Do() ([]*StatementResponse, bool, error) {
+	return c.DoContext(context.Background())
+}
+
+// DoContext はcontext.Contextを指定して取引明細のリストを配列で取得します。
+func (c *StatementListCaller) DoContext(ctx context.Context) ([]*StatementResponse, bool, error) {
+	var hasMore bool
+	result, err := c.service.call(ctx, "statement.list", "", func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.queryList(ctx, "/statements", c.limit, c.offset, c.since, c.until, func(values *url.Values) bool {
+			result := false
+			if c.owner != "" {
+				values.Add("owner", c.owner)
+				result = true
+			}
+			if c.tenantID != "" {
+				values.Add("tenant", c.tenantID)
+				result = true
+			}
+			if c.termID != "" {
+				values.Add("term", c.termID)
+				result = true
+			}
+			if c.statementType != "" {
+				values.Add("type", c.statementType)
+				result = true
+			}
+			return result
+		})
+		if err != nil {
+			return nil, err
+		}
+		raw := &listResponseParser{}
+		err = json.Unmarshal(body, raw)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]*StatementResponse, len(raw.Data))
+		for i, rawStatement := range raw.Data {
+			statement := &StatementResponse{}
+			json.Unmarshal(rawStatement, statement)
+			statement.setService(c.service)
+			result[i] = statement
+		}
+		hasMore = raw.HasMore
+		return result, nil
+	})
+	list, _ := result.([]*StatementResponse)
+	return list, hasMore, err
+}
+
+// Iter は取引明細のリストを順に取得するイテレータを返します。次のページは自動的に取得されます。
+func (c *StatementListCaller) Iter() *StatementIter {
+	return c.IterContext(context.Background())
+}
+
+// IterContext はcontext.Contextを指定してイテレータを返します。ctxがキャンセルされると取得を中断します。
+func (c *StatementListCaller) IterContext(ctx context.Context) *StatementIter {
+	caller := *c
+	return &StatementIter{newListIter(ctx, c.offset, c.limit, func(ctx context.Context, offset, limit int) ([]interface{}, bool, error) {
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	})}
+}
+
+// StatementIter は取引明細のリストを順に取得するイテレータです。
+type StatementIter struct {
+	listIter
+}
+
+// Max は取得する要素数の上限を設定します。0の場合は上限なしです。
+func (it *StatementIter) Max(n int) *StatementIter {
+	it.max = n
+	return it
+}
+
+// Value は現在の要素を返します。
+func (it *StatementIter) Value() *StatementResponse {
+	value, _ := it.current.(*StatementResponse)
+	return value
+}
+
+// StatementItem は取引明細の項目です。
+type StatementItem struct {
+	Subject string // 項目の種別(e.g. gross_sales, fee)
+	Name    string // 項目名
+	Amount  int    // 金額
+	TaxRate string // 消費税率
+}
+
+// StatementResponse はStatementService.Retrieve、StatementService.Listで返される取引明細を表す構造体です
+type StatementResponse struct {
+	ID        string           // st_で始まる一意なオブジェクトを示す文字列
+	LiveMode  bool             // 本番環境かどうか
+	CreatedAt time.Time        // この取引明細作成時のタイムスタンプ
+	UpdatedAt time.Time        // この取引明細更新時のタイムスタンプ
+	Title     string           // 取引明細のタイトル
+	Type      string           // 取引明細の種別
+	Net       int              // 取引明細の合計金額
+	TenantID  string           // テナントID(テナントの取引明細のみ)
+	BalanceID string           // この取引明細が含まれる残高のID
+	Term      *TermResponse    // この取引明細の集計区間
+	Items     []*StatementItem // 取引明細の項目
+
+	service *Service
+}
+
+type statementItemParser struct {
+	Amount  int    `json:"amount"`
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	TaxRate string `json:"tax_rate"`
+}
+
+type statementResponseParser struct {
+	BalanceID    string                 `json:"balance_id"`
+	CreatedEpoch int                    `json:"created"`
+	ID           string                 `json:"id"`
+	Items        []*statementItemParser `json:"items"`
+	LiveMode     bool                   `json:"livemode"`
+	Net          int                    `json:"net"`
+	Object       string                 `json:"object"`
+	TenantID     string                 `json:"tenant_id"`
+	Term         json.RawMessage        `json:"term"`
+	Title        string                 `json:"title"`
+	Type         string                 `json:"type"`
+	UpdatedEpoch int                    `json:"updated"`
+}
+
+func (s *StatementResponse) setService(service *Service) {
+	s.service = service
+	if s.Term != nil {
+		s.Term.service = service
+	}
+}
+
+// CreateStatementURL は取引明細のダウンロードURLを作成します。
+func (s *StatementResponse) CreateStatementURL() (*StatementURLResponse, error) {
+	return s.service.Statement.CreateStatementURL(s.ID)
+}
+
+// CreateStatementURLWithContext はcontext.Contextを指定して取引明細のダウンロードURLを作成します。
+func (s *StatementResponse) CreateStatementURLWithContext(ctx context.Context) (*StatementURLResponse, error) {
+	return s.service.Statement.CreateStatementURLWithContext(ctx, s.ID)
+}
+
+// Download は取引明細のファイルをwに書き込みます。
+func (s *StatementResponse) Download(w io.Writer) (int64, error) {
+	return s.service.Statement.Download(s.ID, w)
+}
+
+// DownloadWithContext はcontext.Contextを指定して取引明細のファイルをwに書き込みます。
+func (s *StatementResponse) DownloadWithContext(ctx context.Context, w io.Writer) (int64, error) {
+	return s.service.Statement.DownloadWithContext(ctx, s.ID, w)
+}
+
+// UnmarshalJSON はJSONパース用の内部APIです。
+func (s *StatementResponse) UnmarshalJSON(b []byte) error {
+	raw := statementResponseParser{}
+	err := json.Unmarshal(b, &raw)
+	if err == nil && raw.Object == "statement" {
+		s.BalanceID = raw.BalanceID
+		s.CreatedAt = time.Unix(int64(raw.CreatedEpoch), 0)
+		s.ID = raw.ID
+		s.Items = make([]*StatementItem, len(raw.Items))
+		for i, item := range raw.Items {
+			s.Items[i] = &StatementItem{
+				Subject: item.Subject,
+				Name:    item.Name,
+				Amount:  item.Amount,
+				TaxRate: item.TaxRate,
+			}
+		}
+		s.LiveMode = raw.LiveMode
+		s.Net = raw.Net
+		s.TenantID = raw.TenantID
+		s.Term = nil
+		if len(raw.Term) > 0 && string(raw.Term) != "null" {
+			s.Term = &TermResponse{}
+			json.Unmarshal(raw.Term, s.Term)
+		}
+		s.Title = raw.Title
+		s.Type = raw.Type
+		s.UpdatedAt = time.Unix(int64(raw.UpdatedEpoch), 0)
+		return nil
+	}
+	rawError := errorResponse{}
+	err = json.Unmarshal(b, &rawError)
+	if err == nil && rawError.Error.Status != 0 {
+		return &rawError.Error
+	}
+
+	return nil
+}
+
+// StatementURLResponse は取引明細のダウンロードURLを表す構造体です
+type StatementURLResponse struct {
+	URL       string    // ダウンロードURL
+	ExpiresAt time.Time // URLの有効期限
+}
+
+type statementURLResponseParser struct {
+	Expires int    `json:"expires"`
+	Object  string `json:"object"`
+	URL     string `json:"url"`
+}
+
+// UnmarshalJSON はJSONパース用の内部APIです。
+func (s *StatementURLResponse) UnmarshalJSON(b []byte) error {
+	raw := statementURLResponseParser{}
+	err := json.Unmarshal(b, &raw)
+	if err == nil && raw.Object == "statement_url" {
+		s.URL = raw.URL
+		s.ExpiresAt = time.Unix(int64(raw.Expires), 0)
+		return nil
+	}
+	rawError := errorResponse{}
+	err = json.Unmarshal(b, &rawError)
+	if err == nil && rawError.Error.Status != 0 {
+		return &rawError.Error
+	}
+
+	return nil
+}
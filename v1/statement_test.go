@@ -0,0 +1,235 @@
+package payjp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var statementResponseJSON = []byte(`
+{
+  "balance_id": "ba_sample_balance",
+  "created": 1695892351,
+  "id": "st_178fd25dc7ab7b75906f5d4c4b104",
+  "items": [
+    {
+      "amount": 25,
+      "name": "チャージバックによる手数料返還",
+      "subject": "chargeback_fee_offset",
+      "tax_rate": "0.00"
+    },
+    {
+      "amount": -1000,
+      "name": "チャージバック",
+      "subject": "chargeback",
+      "tax_rate": "0.00"
+    }
+  ],
+  "livemode": true,
+  "net": -975,
+  "object": "statement",
+  "tenant_id": null,
+  "term": {
+    "charge_count": 1,
+    "closed": true,
+    "dispute_count": 1,
+    "end_at": 1696086000,
+    "id": "tm_b92b879e60f62b532d6756ae12af",
+    "livemode": true,
+    "object": "term",
+    "refund_count": 0,
+    "start_at": 1695481200
+  },
+  "title": null,
+  "type": "sales",
+  "updated": 1695892351
+}
+`)
+
+var statementListResponseJSON = []byte(`
+{
+  "count": 1,
+  "data": [` + string(statementResponseJSON) + `],
+  "has_more": false,
+  "object": "list",
+  "url": "/v1/statements"
+}
+`)
+
+var statementURLResponseJSON = []byte(`
+{
+  "expires": 1695903280,
+  "object": "statement_url",
+  "url": "https://pay.jp/_/statements/8f9ec721bc734dbb"
+}
+`)
+
+func TestParseStatementResponseJSON(t *testing.T) {
+	statement := &StatementResponse{}
+	err := json.Unmarshal(statementResponseJSON, statement)
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+	if statement.ID != "st_178fd25dc7ab7b75906f5d4c4b104" || statement.Net != -975 || statement.Type != "sales" || statement.BalanceID != "ba_sample_balance" {
+		t.Errorf("parse error: %#v", statement)
+	}
+	if len(statement.Items) != 2 || statement.Items[1].Subject != "chargeback" || statement.Items[1].Amount != -1000 || statement.Items[1].TaxRate != "0.00" {
+		t.Errorf("parse error: statement.Items %v", statement.Items)
+	}
+	if statement.Term == nil || statement.Term.ID != "tm_b92b879e60f62b532d6756ae12af" || !statement.Term.Closed || statement.Term.EndAt.Unix() != 1696086000 {
+		t.Errorf("parse error: statement.Term %#v", statement.Term)
+	}
+}
+
+func TestStatementRetrieve(t *testing.T) {
+	mock, transport := NewMockClient(200, statementResponseJSON)
+	service := New("api-key", mock)
+	statement, err := service.Statement.Retrieve("st_178fd25dc7ab7b75906f5d4c4b104")
+	if transport.URL != "https://api.pay.jp/v1/statements/st_178fd25dc7ab7b75906f5d4c4b104" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if transport.Method != "GET" {
+		t.Errorf("Method should be GET, but %s", transport.Method)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	if statement.ID != "st_178fd25dc7ab7b75906f5d4c4b104" {
+		t.Errorf("statement.ID should be 'st_178fd25dc7ab7b75906f5d4c4b104', but '%s'", statement.ID)
+	}
+}
+
+func TestStatementList(t *testing.T) {
+	mock, transport := NewMockClient(200, statementListResponseJSON)
+	service := New("api-key", mock)
+	statements, hasMore, err := service.Statement.List().
+		Limit(10).
+		Offset(15).
+		Since(time.Unix(1455328095, 0)).
+		Until(time.Unix(1455500895, 0)).
+		Owner("tenant").
+		TenantID("ten_121673955bd7aa144de5a8f6c262").
+		TermID("tm_b92b879e60f62b532d6756ae12af").
+		Type("sales").Do()
+	if transport.URL != "https://api.pay.jp/v1/statements?limit=10&offset=15&owner=tenant&since=1455328095&tenant=ten_121673955bd7aa144de5a8f6c262&term=tm_b92b879e60f62b532d6756ae12af&type=sales&until=1455500895" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	if hasMore {
+		t.Error("parse error: hasMore")
+	}
+	if len(statements) != 1 || statements[0].Term.ID != "tm_b92b879e60f62b532d6756ae12af" {
+		t.Errorf("parse error: statements %v", statements)
+	}
+}
+
+func TestStatementCreateStatementURL(t *testing.T) {
+	mock, transport := NewMockClient(200, statementURLResponseJSON)
+	service := New("api-key", mock)
+	statementURL, err := service.Statement.CreateStatementURL("st_178fd25dc7ab7b75906f5d4c4b104")
+	if transport.URL != "https://api.pay.jp/v1/statements/st_178fd25dc7ab7b75906f5d4c4b104/statement_urls" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if transport.Method != "POST" {
+		t.Errorf("Method should be POST, but %s", transport.Method)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	if statementURL.URL != "https://pay.jp/_/statements/8f9ec721bc734dbb" || statementURL.ExpiresAt.Unix() != 1695903280 {
+		t.Errorf("parse error: %#v", statementURL)
+	}
+}
+
+func newStatementDownloadServer(t *testing.T, csv string) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/statements/st_178fd25dc7ab7b75906f5d4c4b104/statement_urls":
+			w.Write([]byte(`{"expires": 1695903280, "object": "statement_url", "url": "` + server.URL + `/files/statement.csv"}`))
+		case "/files/statement.csv":
+			if r.Header.Get("Authorization") != "" {
+				t.Errorf("API key should not be sent to the download URL")
+			}
+			w.Write([]byte(csv))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func TestStatementDownload(t *testing.T) {
+	csv := "subject,name,amount\nchargeback,チャージバック,-1000\n"
+	server := newStatementDownloadServer(t, csv)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+
+	buf := &bytes.Buffer{}
+	n, err := service.Statement.Download("st_178fd25dc7ab7b75906f5d4c4b104", buf)
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if n != int64(len(csv)) || buf.String() != csv {
+		t.Errorf("downloaded file is wrong (%d bytes): %q", n, buf.String())
+	}
+
+	buf.Reset()
+	_, err = service.Statement.Download("st_unknown", buf)
+	var payjpError *Error
+	if !errors.As(err, &payjpError) || payjpError.Status != http.StatusNotFound {
+		t.Errorf("err should be 404 *Error, but %v", err)
+	}
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
+
+func TestStatementDownloadWriterError(t *testing.T) {
+	server := newStatementDownloadServer(t, "subject,name,amount\n")
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+
+	writeErr := errors.New("no space left on device")
+	_, err := service.Statement.Download("st_178fd25dc7ab7b75906f5d4c4b104", failingWriter{err: writeErr})
+	if err != writeErr {
+		t.Errorf("writer error should be returned as is, but %v", err)
+	}
+	if errors.Is(err, ErrNetwork) || IsRetryable(err) {
+		t.Errorf("writer error should not be network error: %v", err)
+	}
+}
+
+func TestStatementDownloadReadError(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/files/statement.csv" {
+			// 宣言した長さより短いボディで接続を切る
+			w.Header().Set("Content-Length", "100")
+			w.Write([]byte("subject,name,amount\n"))
+			return
+		}
+		w.Write([]byte(`{"expires": 1695903280, "object": "statement_url", "url": "` + server.URL + `/files/statement.csv"}`))
+	}))
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+
+	_, err := service.Statement.Download("st_178fd25dc7ab7b75906f5d4c4b104", &bytes.Buffer{})
+	if !errors.Is(err, ErrNetwork) {
+		t.Errorf("read error should be network error, but %v", err)
+	}
+}
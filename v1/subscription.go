@@ -1,11 +1,10 @@
 package payjp
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -57,12 +56,12 @@ func newSubscriptionService(service *Service) *SubscriptionService {
 
 // Subscription はSubscribeやUpdateの引数を設定するのに使用する構造体です。
 type Subscription struct {
-	TrialEndAt time.Time         // トライアルの終了時期
-	SkipTrial  interface{}       // トライアルをしない(bool)
-	PlanID     interface{}       // プランID(string)
-	NextCyclePlanID interface{}  // 次サイクルから適用するプランID(string, 更新時のみ設定可能)
-	Prorate    interface{}       // 日割り課金をするかどうか(bool)
-	Metadata   map[string]string // メタデータ
+	TrialEndAt      time.Time `form:"trial_end,omitempty"` // トライアルの終了時期
+	SkipTrial       *bool     `form:"-"`                   // トライアルをしない
+	PlanID          *string   `form:"plan"`                // プランID
+	NextCyclePlanID *string   `form:"next_cycle_plan"`     // 次サイクルから適用するプランID(更新時のみ設定可能)。String("")で予約を取り消します
+	Prorate         *bool     `form:"prorate"`             // 日割り課金をするかどうか
+	Metadata        Metadata  `form:"metadata"`            // メタデータ
 }
 
 // Subscribe は顧客IDとプランIDを指定して、定期課金を開始することができます。
@@ -72,74 +71,77 @@ type Subscription struct {
 // 作成時よりもあとの支払い実行日に最初の課金が行われます。またトライアル設定がある場合は、
 // トライアル終了時に支払い処理が行われ、そこを基準にして定期課金が開始されます。
 func (s SubscriptionService) Subscribe(customerID string, subscription Subscription) (*SubscriptionResponse, error) {
-	var errors []string
-	planID, ok := subscription.PlanID.(string)
-	if !ok || planID == "" {
-		errors = append(errors, "PlanID is required, but empty.")
-	}
-	var defaultTime time.Time
-	skipTrial, ok := subscription.SkipTrial.(bool)
-	if subscription.TrialEndAt != defaultTime && ok {
-		errors = append(errors, "TrialEndAt and SkipTrial are exclusive.")
-	}
-	if len(errors) != 0 {
-		return nil, fmt.Errorf("Subscription.Subscribe() parameter error: %s", strings.Join(errors, ", "))
-	}
-	qb := newRequestBuilder()
-	qb.Add("customer", customerID)
-	qb.Add("plan", subscription.PlanID)
-	if subscription.TrialEndAt != defaultTime {
-		qb.Add("trial_end", strconv.Itoa(int(subscription.TrialEndAt.Unix())))
-	} else if ok && skipTrial {
-		qb.Add("trial_end", "now")
-	}
-	qb.Add("prorate", subscription.Prorate)
-	qb.AddMetadata(subscription.Metadata)
-	request, err := http.NewRequest("POST", s.service.apiBase+"/subscriptions", qb.Reader())
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	request.Header.Add("Authorization", s.service.apiKey)
+	return s.SubscribeWithContext(context.Background(), customerID, subscription)
+}
 
-	body, err := respToBody(s.service.Client.Do(request))
-	if err != nil {
-		return nil, err
-	}
-	return parseSubscription(s.service, body, &SubscriptionResponse{})
+// SubscribeWithContext はcontext.Contextを指定して定期課金を開始します。
+func (s SubscriptionService) SubscribeWithContext(ctx context.Context, customerID string, subscription Subscription) (*SubscriptionResponse, error) {
+	result, err := s.service.call(ctx, "subscription.create", "", func(ctx context.Context) (interface{}, error) {
+		var errors []string
+		if subscription.PlanID == nil || *subscription.PlanID == "" {
+			errors = append(errors, "PlanID is required, but empty.")
+		}
+		var defaultTime time.Time
+		if subscription.TrialEndAt != defaultTime && subscription.SkipTrial != nil {
+			errors = append(errors, "TrialEndAt and SkipTrial are exclusive.")
+		}
+		if len(errors) != 0 {
+			return nil, fmt.Errorf("Subscription.Subscribe() parameter error: %s", strings.Join(errors, ", "))
+		}
+		subscription.NextCyclePlanID = nil
+		values, err := encodeForm(struct {
+			CustomerID string `form:"customer"`
+			Subscription
+		}{customerID, subscription})
+		if err != nil {
+			return nil, err
+		}
+		if subscription.SkipTrial != nil && *subscription.SkipTrial {
+			values.Set("trial_end", "now")
+		}
+
+		body, err := respToBody(s.service.request(ctx, "POST", "/subscriptions", formBody(values)))
+		if err != nil {
+			return nil, err
+		}
+		return parseSubscription(s.service, body, &SubscriptionResponse{})
+	})
+	response, _ := result.(*SubscriptionResponse)
+	return response, err
 }
 
 // Retrieve subscription object. 特定の定期課金情報を取得します。
 func (s SubscriptionService) Retrieve(customerID, subscriptionID string) (*SubscriptionResponse, error) {
-	body, err := s.service.retrieve("/customers/" + customerID + "/subscriptions/" + subscriptionID)
-	if err != nil {
-		return nil, err
-	}
-	return parseSubscription(s.service, body, &SubscriptionResponse{})
+	return s.RetrieveWithContext(context.Background(), customerID, subscriptionID)
+}
+
+// RetrieveWithContext はcontext.Contextを指定して特定の定期課金情報を取得します。
+func (s SubscriptionService) RetrieveWithContext(ctx context.Context, customerID, subscriptionID string) (*SubscriptionResponse, error) {
+	result, err := s.service.call(ctx, "subscription.retrieve", subscriptionID, func(ctx context.Context) (interface{}, error) {
+		body, err := s.service.retrieve(ctx, "/customers/"+customerID+"/subscriptions/"+subscriptionID)
+		if err != nil {
+			return nil, err
+		}
+		return parseSubscription(s.service, body, &SubscriptionResponse{})
+	})
+	response, _ := result.(*SubscriptionResponse)
+	return response, err
 }
 
-func (s SubscriptionService) update(subscriptionID string, subscription Subscription) ([]byte, error) {
+func (s SubscriptionService) update(ctx context.Context, subscriptionID string, subscription Subscription) ([]byte, error) {
 	var defaultTime time.Time
-	_, ok := subscription.SkipTrial.(bool)
-	if subscription.TrialEndAt != defaultTime && ok {
+	if subscription.TrialEndAt != defaultTime && subscription.SkipTrial != nil {
 		return nil, errors.New("Subscription.Update() parameter error: TrialEndAt and SkipTrial are exclusive")
 	}
-	qb := newRequestBuilder()
-	qb.Add("next_cycle_plan", subscription.NextCyclePlanID)
-	qb.Add("plan", subscription.PlanID)
-	if subscription.TrialEndAt != defaultTime {
-		qb.Add("trial_end", strconv.Itoa(int(subscription.TrialEndAt.Unix())))
-	} else if subscription.SkipTrial == true {
-		qb.Add("trial_end", "now")
-	}
-	qb.Add("prorate", subscription.Prorate)
-	request, err := http.NewRequest("POST", s.service.apiBase+"/subscriptions/"+subscriptionID, qb.Reader())
+	values, err := encodeForm(subscription)
 	if err != nil {
 		return nil, err
 	}
-	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	request.Header.Add("Authorization", s.service.apiKey)
-	return parseResponseError(s.service.Client.Do(request))
+	if subscription.SkipTrial != nil && *subscription.SkipTrial {
+		values.Set("trial_end", "now")
+	}
+
+	return parseResponseError(s.service.request(ctx, "POST", "/subscriptions/"+subscriptionID, formBody(values)))
 }
 
 // Update はトライアル期間を新たに設定したり、プランの変更を行うことができます。
@@ -152,27 +154,44 @@ func (s SubscriptionService) update(subscriptionID string, subscription Subscrip
 // プランを変更する場合は、 PlanID に新しいプランのIDを指定してください。
 // 同時に Prorate=true とする事により、 日割り課金を有効化できます。
 func (s SubscriptionService) Update(subscriptionID string, subscription Subscription) (*SubscriptionResponse, error) {
-	body, err := s.update(subscriptionID, subscription)
-	if err != nil {
-		return nil, err
-	}
-	return parseSubscription(s.service, body, &SubscriptionResponse{})
+	return s.UpdateWithContext(context.Background(), subscriptionID, subscription)
+}
+
+// UpdateWithContext はcontext.Contextを指定して定期課金を更新します。
+func (s SubscriptionService) UpdateWithContext(ctx context.Context, subscriptionID string, subscription Subscription) (*SubscriptionResponse, error) {
+	result, err := s.service.call(ctx, "subscription.update", subscriptionID, func(ctx context.Context) (interface{}, error) {
+		body, err := s.update(ctx, subscriptionID, subscription)
+		if err != nil {
+			return nil, err
+		}
+		return parseSubscription(s.service, body, &SubscriptionResponse{})
+	})
+	response, _ := result.(*SubscriptionResponse)
+	return response, err
 }
 
 // Pause は引き落としの失敗やカードが不正である、また定期課金を停止したい場合はこのリクエストで定期購入を停止させます。
 //
 // 定期課金を停止させると、再開されるまで引き落とし処理は一切行われません。
 func (s SubscriptionService) Pause(subscriptionID string) (*SubscriptionResponse, error) {
-	request, err := http.NewRequest("POST", s.service.apiBase+"/subscriptions/"+subscriptionID+"/pause", nil)
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Add("Authorization", s.service.apiKey)
-	body, err := respToBody(s.service.Client.Do(request))
-	if err != nil {
-		return nil, err
-	}
-	return parseSubscription(s.service, body, &SubscriptionResponse{})
+	return s.PauseWithContext(context.Background(), subscriptionID)
+}
+
+// PauseWithContext はcontext.Contextを指定して定期課金を停止させます。
+func (s SubscriptionService) PauseWithContext(ctx context.Context, subscriptionID string) (*SubscriptionResponse, error) {
+	result, err := s.service.call(ctx, "subscription.pause", subscriptionID, func(ctx context.Context) (interface{}, error) {
+		body, err := s.pause(ctx, subscriptionID)
+		if err != nil {
+			return nil, err
+		}
+		return parseSubscription(s.service, body, &SubscriptionResponse{})
+	})
+	response, _ := result.(*SubscriptionResponse)
+	return response, err
+}
+
+func (s SubscriptionService) pause(ctx context.Context, subscriptionID string) ([]byte, error) {
+	return respToBody(s.service.request(ctx, "POST", "/subscriptions/"+subscriptionID+"/pause", nil))
 }
 
 // Resume は停止もしくはキャンセル状態の定期課金を再開させます。
@@ -189,23 +208,32 @@ func (s SubscriptionService) Pause(subscriptionID string) (*SubscriptionResponse
 // またProrate を指定することで、日割り課金を有効化することができます。 日割り課金が有効な場合は、
 // 再開日より課金日までの日数分で課金額を日割りします。
 func (s SubscriptionService) Resume(subscriptionID string, subscription Subscription) (*SubscriptionResponse, error) {
-	var defaultTime time.Time
-	qb := newRequestBuilder()
-	if subscription.TrialEndAt != defaultTime {
-		qb.Add("trial_end", strconv.Itoa(int(subscription.TrialEndAt.Unix())))
-	}
-	qb.Add("prorate", subscription.Prorate)
+	return s.ResumeWithContext(context.Background(), subscriptionID, subscription)
+}
 
-	request, err := http.NewRequest("POST", s.service.apiBase+"/subscriptions/"+subscriptionID+"/resume", qb.Reader())
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Add("Authorization", s.service.apiKey)
-	body, err := respToBody(s.service.Client.Do(request))
+// ResumeWithContext はcontext.Contextを指定して停止もしくはキャンセル状態の定期課金を再開させます。
+func (s SubscriptionService) ResumeWithContext(ctx context.Context, subscriptionID string, subscription Subscription) (*SubscriptionResponse, error) {
+	result, err := s.service.call(ctx, "subscription.resume", subscriptionID, func(ctx context.Context) (interface{}, error) {
+		body, err := s.resume(ctx, subscriptionID, subscription)
+		if err != nil {
+			return nil, err
+		}
+		return parseSubscription(s.service, body, &SubscriptionResponse{})
+	})
+	response, _ := result.(*SubscriptionResponse)
+	return response, err
+}
+
+func (s SubscriptionService) resume(ctx context.Context, subscriptionID string, subscription Subscription) ([]byte, error) {
+	values, err := encodeForm(struct {
+		TrialEndAt time.Time `form:"trial_end,omitempty"`
+		Prorate    *bool     `form:"prorate"`
+	}{subscription.TrialEndAt, subscription.Prorate})
 	if err != nil {
 		return nil, err
 	}
-	return parseSubscription(s.service, body, &SubscriptionResponse{})
+
+	return respToBody(s.service.request(ctx, "POST", "/subscriptions/"+subscriptionID+"/resume", formBody(values)))
 }
 
 // Cancel は定期課金をキャンセルし、現在の周期の終了日をもって定期課金を終了させます。
@@ -214,27 +242,37 @@ func (s SubscriptionService) Resume(subscriptionID string, subscription Subscrip
 // キャンセルを取り消すことができます。終了日をむかえた定期課金は、
 // 自動的に削除されますのでご注意ください。
 func (s SubscriptionService) Cancel(subscriptionID string) (*SubscriptionResponse, error) {
-	request, err := http.NewRequest("POST", s.service.apiBase+"/subscriptions/"+subscriptionID+"/cancel", nil)
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Add("Authorization", s.service.apiKey)
-	body, err := respToBody(s.service.Client.Do(request))
-	if err != nil {
-		return nil, err
-	}
-	return parseSubscription(s.service, body, &SubscriptionResponse{})
+	return s.CancelWithContext(context.Background(), subscriptionID)
+}
+
+// CancelWithContext はcontext.Contextを指定して定期課金をキャンセルします。
+func (s SubscriptionService) CancelWithContext(ctx context.Context, subscriptionID string) (*SubscriptionResponse, error) {
+	result, err := s.service.call(ctx, "subscription.cancel", subscriptionID, func(ctx context.Context) (interface{}, error) {
+		body, err := s.cancel(ctx, subscriptionID)
+		if err != nil {
+			return nil, err
+		}
+		return parseSubscription(s.service, body, &SubscriptionResponse{})
+	})
+	response, _ := result.(*SubscriptionResponse)
+	return response, err
+}
+
+func (s SubscriptionService) cancel(ctx context.Context, subscriptionID string) ([]byte, error) {
+	return respToBody(s.service.request(ctx, "POST", "/subscriptions/"+subscriptionID+"/cancel", nil))
 }
 
 // Delete は定期課金をすぐに削除します。次回以降の課金は行われずに、一度削除した定期課金は、
 // 再び戻すことができません。
 func (s SubscriptionService) Delete(subscriptionID string) error {
-	request, err := http.NewRequest("DELETE", s.service.apiBase+"/subscriptions/"+subscriptionID, nil)
-	if err != nil {
-		return err
-	}
-	request.Header.Add("Authorization", s.service.apiKey)
-	_, err = parseResponseError(s.service.Client.Do(request))
+	return s.DeleteWithContext(context.Background(), subscriptionID)
+}
+
+// DeleteWithContext はcontext.Contextを指定して定期課金をすぐに削除します。
+func (s SubscriptionService) DeleteWithContext(ctx context.Context, subscriptionID string) error {
+	_, err := s.service.call(ctx, "subscription.delete", subscriptionID, func(ctx context.Context) (interface{}, error) {
+		return nil, s.service.delete(ctx, "/subscriptions/"+subscriptionID)
+	})
 	return err
 }
 
@@ -301,76 +339,81 @@ type subscriptionResponseParser struct {
 
 // Update はトライアル期間を新たに設定したり、プランの変更を行うことができます。
 func (s *SubscriptionResponse) Update(subscription Subscription) error {
-	body, err := s.service.Subscription.update(s.ID, subscription)
-	if err != nil {
-		return err
-	}
-	_, err = parseSubscription(s.service, body, s)
+	return s.UpdateWithContext(context.Background(), subscription)
+}
+
+// UpdateWithContext はcontext.Contextを指定して定期課金を更新します。
+func (s *SubscriptionResponse) UpdateWithContext(ctx context.Context, subscription Subscription) error {
+	_, err := s.service.call(ctx, "subscription.update", s.ID, func(ctx context.Context) (interface{}, error) {
+		body, err := s.service.Subscription.update(ctx, s.ID, subscription)
+		if err != nil {
+			return nil, err
+		}
+		return parseSubscription(s.service, body, s)
+	})
 	return err
 }
 
 // Pause は引き落としの失敗やカードが不正である、また定期課金を停止したい場合はこのリクエストで定期購入を停止させます。
 func (s *SubscriptionResponse) Pause() error {
-	request, err := http.NewRequest("POST", s.service.apiBase+"/subscriptions/"+s.ID+"/pause", nil)
-	if err != nil {
-		return err
-	}
-	request.Header.Add("Authorization", s.service.apiKey)
-	body, err := respToBody(s.service.Client.Do(request))
-	if err != nil {
-		return err
-	}
-	_, err = parseSubscription(s.service, body, s)
+	return s.PauseWithContext(context.Background())
+}
+
+// PauseWithContext はcontext.Contextを指定して定期課金を停止させます。
+func (s *SubscriptionResponse) PauseWithContext(ctx context.Context) error {
+	_, err := s.service.call(ctx, "subscription.pause", s.ID, func(ctx context.Context) (interface{}, error) {
+		body, err := s.service.Subscription.pause(ctx, s.ID)
+		if err != nil {
+			return nil, err
+		}
+		return parseSubscription(s.service, body, s)
+	})
 	return err
 }
 
 // Resume は停止もしくはキャンセル状態の定期課金を再開させます。
 func (s *SubscriptionResponse) Resume(subscription Subscription) error {
-	var defaultTime time.Time
-	qb := newRequestBuilder()
-	if subscription.TrialEndAt != defaultTime {
-		qb.Add("trial_end", strconv.Itoa(int(subscription.TrialEndAt.Unix())))
-	}
-	qb.Add("prorate", subscription.Prorate)
+	return s.ResumeWithContext(context.Background(), subscription)
+}
 
-	request, err := http.NewRequest("POST", s.service.apiBase+"/subscriptions/"+s.ID+"/resume", qb.Reader())
-	if err != nil {
-		return err
-	}
-	request.Header.Add("Authorization", s.service.apiKey)
-	body, err := respToBody(s.service.Client.Do(request))
-	if err != nil {
-		return err
-	}
-	_, err = parseSubscription(s.service, body, s)
+// ResumeWithContext はcontext.Contextを指定して停止もしくはキャンセル状態の定期課金を再開させます。
+func (s *SubscriptionResponse) ResumeWithContext(ctx context.Context, subscription Subscription) error {
+	_, err := s.service.call(ctx, "subscription.resume", s.ID, func(ctx context.Context) (interface{}, error) {
+		body, err := s.service.Subscription.resume(ctx, s.ID, subscription)
+		if err != nil {
+			return nil, err
+		}
+		return parseSubscription(s.service, body, s)
+	})
 	return err
 }
 
 // Cancel は定期課金をキャンセルし、現在の周期の終了日をもって定期課金を終了させます。
 func (s *SubscriptionResponse) Cancel() error {
-	request, err := http.NewRequest("POST", s.service.apiBase+"/subscriptions/"+s.ID+"/cancel", nil)
-	if err != nil {
-		return err
-	}
-	request.Header.Add("Authorization", s.service.apiKey)
-	body, err := respToBody(s.service.Client.Do(request))
-	if err != nil {
-		return err
-	}
-	_, err = parseSubscription(s.service, body, s)
+	return s.CancelWithContext(context.Background())
+}
+
+// CancelWithContext はcontext.Contextを指定して定期課金をキャンセルします。
+func (s *SubscriptionResponse) CancelWithContext(ctx context.Context) error {
+	_, err := s.service.call(ctx, "subscription.cancel", s.ID, func(ctx context.Context) (interface{}, error) {
+		body, err := s.service.Subscription.cancel(ctx, s.ID)
+		if err != nil {
+			return nil, err
+		}
+		return parseSubscription(s.service, body, s)
+	})
 	return err
 }
 
 // Delete は定期課金をすぐに削除します。次回以降の課金は行われずに、一度削除した定期課金は、
 // 再び戻すことができません。
 func (s *SubscriptionResponse) Delete() error {
-	request, err := http.NewRequest("DELETE", s.service.apiBase+"/subscriptions/"+s.ID, nil)
-	if err != nil {
-		return err
-	}
-	request.Header.Add("Authorization", s.service.apiKey)
-	_, err = parseResponseError(s.service.Client.Do(request))
-	return err
+	return s.DeleteWithContext(context.Background())
+}
+
+// DeleteWithContext はcontext.Contextを指定して定期課金をすぐに削除します。
+func (s *SubscriptionResponse) DeleteWithContext(ctx context.Context) error {
+	return s.service.Subscription.DeleteWithContext(ctx, s.ID)
 }
 
 // UnmarshalJSON はJSONパース用の内部APIです。
@@ -458,26 +501,74 @@ func (c *SubscriptionListCaller) PlanID(planID string) *SubscriptionListCaller {
 
 // Do は指定されたクエリーを元に顧客のリストを配列で取得します。
 func (c *SubscriptionListCaller) Do() ([]*SubscriptionResponse, bool, error) {
-	var url string
-	if c.customerID == "" {
-		url = "/subscriptions"
-	} else {
-		url = "/customers/" + c.customerID + "/subscriptions"
-	}
-	body, err := c.service.queryList(url, c.limit, c.offset, c.since, c.until)
-	if err != nil {
-		return nil, false, err
-	}
-	raw := &listResponseParser{}
-	err = json.Unmarshal(body, raw)
-	if err != nil {
-		return nil, false, err
-	}
-	result := make([]*SubscriptionResponse, len(raw.Data))
-	for i, rawSubscription := range raw.Data {
-		subscription := &SubscriptionResponse{}
-		json.Unmarshal(rawSubscription, subscription)
-		result[i] = subscription
-	}
-	return result, raw.HasMore, nil
+	return c.DoContext(context.Background())
+}
+
+// DoContext はcontext.Contextを指定して定期課金のリストを配列で取得します。
+func (c *SubscriptionListCaller) DoContext(ctx context.Context) ([]*SubscriptionResponse, bool, error) {
+	var hasMore bool
+	result, err := c.service.call(ctx, "subscription.list", "", func(ctx context.Context) (interface{}, error) {
+		var url string
+		if c.customerID == "" {
+			url = "/subscriptions"
+		} else {
+			url = "/customers/" + c.customerID + "/subscriptions"
+		}
+		body, err := c.service.queryList(ctx, url, c.limit, c.offset, c.since, c.until)
+		if err != nil {
+			return nil, err
+		}
+		raw := &listResponseParser{}
+		err = json.Unmarshal(body, raw)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]*SubscriptionResponse, len(raw.Data))
+		for i, rawSubscription := range raw.Data {
+			subscription := &SubscriptionResponse{}
+			json.Unmarshal(rawSubscription, subscription)
+			result[i] = subscription
+		}
+		hasMore = raw.HasMore
+		return result, nil
+	})
+	list, _ := result.([]*SubscriptionResponse)
+	return list, hasMore, err
+}
+
+// Iter は定期課金のリストを順に取得するイテレータを返します。次のページは自動的に取得されます。
+func (c *SubscriptionListCaller) Iter() *SubscriptionIter {
+	return c.IterContext(context.Background())
+}
+
+// IterContext はcontext.Contextを指定してイテレータを返します。ctxがキャンセルされると取得を中断します。
+func (c *SubscriptionListCaller) IterContext(ctx context.Context) *SubscriptionIter {
+	caller := *c
+	return &SubscriptionIter{newListIter(ctx, c.offset, c.limit, func(ctx context.Context, offset, limit int) ([]interface{}, bool, error) {
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	})}
+}
+
+// SubscriptionIter は定期課金のリストを順に取得するイテレータです。
+type SubscriptionIter struct {
+	listIter
+}
+
+// Max は取得する要素数の上限を設定します。0の場合は上限なしです。
+func (it *SubscriptionIter) Max(n int) *SubscriptionIter {
+	it.max = n
+	return it
+}
+
+// Value は現在の要素を返します。
+func (it *SubscriptionIter) Value() *SubscriptionResponse {
+	value, _ := it.current.(*SubscriptionResponse)
+	return value
 }
@@ -215,7 +215,7 @@ func TestSubscriptionCreate(t *testing.T) {
 	mock, transport := NewMockClient(200, subscriptionResponseJSON)
 	service := New("api-key", mock)
 	subscription, err := service.Subscription.Subscribe("cus_4df4b5ed720933f4fb9e28857517", Subscription{
-		PlanID: "pln_9589006d14aad86aafeceac06b60",
+		PlanID: String("pln_9589006d14aad86aafeceac06b60"),
 	})
 	if transport.URL != "https://api.pay.jp/v1/subscriptions" {
 		t.Errorf("URL is wrong: %s", transport.URL)
@@ -258,8 +258,8 @@ func TestSubscriptionUpdate(t *testing.T) {
 	mock, transport := NewMockClient(200, subscriptionResponseJSON)
 	service := New("api-key", mock)
 	subscription, err := service.Subscription.Update("sub_567a1e44562932ec1a7682d746e0", Subscription{
-		PlanID: "pln_9589006d14aad86aafeceac06b60",
-		NextCyclePlanID: "next_plan",
+		PlanID: String("pln_9589006d14aad86aafeceac06b60"),
+		NextCyclePlanID: String("next_plan"),
 	})
 	if transport.URL != "https://api.pay.jp/v1/subscriptions/sub_567a1e44562932ec1a7682d746e0" {
 		t.Errorf("URL is wrong: %s", transport.URL)
@@ -282,7 +282,7 @@ func TestSubscriptionUpdate(t *testing.T) {
 	mock2, transport2 := NewMockClient(200, nextCyclePlanNullResponseJSON)
 	service2 := New("api-key", mock2)
 	newSubscr, err := service2.Subscription.Update("sub_567a1e44562932ec1a7682d746e0", Subscription{
-		NextCyclePlanID: "",
+		NextCyclePlanID: String(""),
 	})
 	if transport2.Method != "POST" {
 		t.Errorf("Method should be POST, but %s", transport2.Method)
@@ -292,6 +292,23 @@ func TestSubscriptionUpdate(t *testing.T) {
 	}
 }
 
+func TestSubscriptionUpdateOptionalParams(t *testing.T) {
+	server, form := newFormRecordingServer(nextCyclePlanNullResponseJSON)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+	_, err := service.Subscription.Update("sub_567a1e44562932ec1a7682d746e0", Subscription{
+		NextCyclePlanID: String(""),
+		SkipTrial:       Bool(false),
+		Prorate:         Bool(false),
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if form.Encode() != "next_cycle_plan=&prorate=false" {
+		t.Errorf("form is wrong: %s", form.Encode())
+	}
+}
+
 func TestSubscriptionPause(t *testing.T) {
 	mock, transport := NewMockClient(200, subscriptionResponseJSON)
 	service := New("api-key", mock)
@@ -0,0 +1,436 @@
+package payjp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TenantService はPAY.JP Platformのテナント(出店者)を扱います。
+//
+// テナントを指定して支払いを作成すると、売上はプラットフォーム利用料を差し引いてテナントへ入金されます。
+type TenantService struct {
+	service *Service
+}
+
+func newTenantService(service *Service) *TenantService {
+	return &TenantService{
+		service: service,
+	}
+}
+
+// Tenant はテナントの作成・更新時に使用する構造体です。
+type Tenant struct {
+	ID                    *string  `form:"id"`                       // テナントID(作成時のみ設定可能)
+	Name                  *string  `form:"name"`                     // 作成時は必須: テナント名
+	PlatformFeeRate       *string  `form:"platform_fee_rate"`        // 作成時は必須: プラットフォーム利用料率(%)。小数点以下2桁までの文字列(e.g. "10.15")
+	PayjpFeeIncluded      *bool    `form:"payjp_fee_included"`       // 決済手数料をプラットフォーム利用料に含めるかどうか(作成時のみ設定可能)
+	MinimumTransferAmount *int     `form:"minimum_transfer_amount"`  // 最低入金額
+	BankCode              *string  `form:"bank_code"`                // 金融機関コード
+	BankBranchCode        *string  `form:"bank_branch_code"`         // 支店コード
+	BankAccountType       *string  `form:"bank_account_type"`        // 預金種別("普通"または"当座")
+	BankAccountNumber     *string  `form:"bank_account_number"`      // 口座番号
+	BankAccountHolderName *string  `form:"bank_account_holder_name"` // 口座名義
+	Metadata              Metadata `form:"metadata"`                 // メタデータ
+}
+
+// Create はテナントを作成します。NameとPlatformFeeRateは必須です。
+func (t TenantService) Create(tenant Tenant) (*TenantResponse, error) {
+	return t.CreateWithContext(context.Background(), tenant)
+}
+
+// CreateWithContext はcontext.Contextを指定してテナントを作成します。
+func (t TenantService) CreateWithContext(ctx context.Context, tenant Tenant) (*TenantResponse, error) {
+	result, err := t.service.call(ctx, "tenant.create", "", func(ctx context.Context) (interface{}, error) {
+		var errors []string
+		if tenant.Name == nil || *tenant.Name == "" {
+			errors = append(errors, "Name is required.")
+		}
+		if tenant.PlatformFeeRate == nil || *tenant.PlatformFeeRate == "" {
+			errors = append(errors, "PlatformFeeRate is required.")
+		}
+		if len(errors) != 0 {
+			return nil, fmt.Errorf("payjp.Tenant.Create() parameter error: %s", strings.Join(errors, ", "))
+		}
+		values, err := encodeForm(tenant)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := respToBody(t.service.request(ctx, "POST", "/tenants", formBody(values)))
+		if err != nil {
+			return nil, err
+		}
+		return parseTenant(t.service, body, &TenantResponse{})
+	})
+	response, _ := result.(*TenantResponse)
+	return response, err
+}
+
+// Retrieve はテナント情報を取得します。
+func (t TenantService) Retrieve(id string) (*TenantResponse, error) {
+	return t.RetrieveWithContext(context.Background(), id)
+}
+
+// RetrieveWithContext はcontext.Contextを指定してテナント情報を取得します。
+func (t TenantService) RetrieveWithContext(ctx context.Context, id string) (*TenantResponse, error) {
+	result, err := t.service.call(ctx, "tenant.retrieve", id, func(ctx context.Context) (interface{}, error) {
+		body, err := t.service.retrieve(ctx, "/tenants/"+id)
+		if err != nil {
+			return nil, err
+		}
+		return parseTenant(t.service, body, &TenantResponse{})
+	})
+	response, _ := result.(*TenantResponse)
+	return response, err
+}
+
+func parseTenant(service *Service, body []byte, result *TenantResponse) (*TenantResponse, error) {
+	err := json.Unmarshal(body, result)
+	if err != nil {
+		return nil, err
+	}
+	result.service = service
+	return result, nil
+}
+
+func (t TenantService) update(ctx context.Context, id string, tenant Tenant) ([]byte, error) {
+	tenant.ID = nil
+	tenant.PayjpFeeIncluded = nil
+	values, err := encodeForm(tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponseError(t.service.request(ctx, "POST", "/tenants/"+id, formBody(values)))
+}
+
+// Update はテナント情報を更新します。IDとPayjpFeeIncludedは更新できません。
+func (t TenantService) Update(id string, tenant Tenant) (*TenantResponse, error) {
+	return t.UpdateWithContext(context.Background(), id, tenant)
+}
+
+// UpdateWithContext はcontext.Contextを指定してテナント情報を更新します。
+func (t TenantService) UpdateWithContext(ctx context.Context, id string, tenant Tenant) (*TenantResponse, error) {
+	result, err := t.service.call(ctx, "tenant.update", id, func(ctx context.Context) (interface{}, error) {
+		body, err := t.update(ctx, id, tenant)
+		if err != nil {
+			return nil, err
+		}
+		return parseTenant(t.service, body, &TenantResponse{})
+	})
+	response, _ := result.(*TenantResponse)
+	return response, err
+}
+
+// Delete はテナントを削除します。
+func (t TenantService) Delete(id string) error {
+	return t.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext はcontext.Contextを指定してテナントを削除します。
+func (t TenantService) DeleteWithContext(ctx context.Context, id string) error {
+	_, err := t.service.call(ctx, "tenant.delete", id, func(ctx context.Context) (interface{}, error) {
+		return nil, t.service.delete(ctx, "/tenants/"+id)
+	})
+	return err
+}
+
+// CreateApplicationURL はテナントが本番利用の審査を申請するためのURLを作成します。
+// URLは一度しかアクセスできず、有効期限を過ぎると使用できなくなります。
+func (t TenantService) CreateApplicationURL(id string) (*ApplicationURLResponse, error) {
+	return t.CreateApplicationURLWithContext(context.Background(), id)
+}
+
+// CreateApplicationURLWithContext はcontext.Contextを指定して審査申請URLを作成します。
+func (t TenantService) CreateApplicationURLWithContext(ctx context.Context, id string) (*ApplicationURLResponse, error) {
+	result, err := t.service.call(ctx, "tenant.create_application_url", id, func(ctx context.Context) (interface{}, error) {
+		body, err := respToBody(t.service.request(ctx, "POST", "/tenants/"+id+"/application_urls", nil))
+		if err != nil {
+			return nil, err
+		}
+		result := &ApplicationURLResponse{}
+		err = json.Unmarshal(body, result)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
+	response, _ := result.(*ApplicationURLResponse)
+	return response, err
+}
+
+// List はテナントのリストを取得します。リストは、直近で生成された順番に取得されます。
+func (t TenantService) List() *TenantListCaller {
+	return &TenantListCaller{
+		service: t.service,
+	}
+}
+
+// TenantListCaller はテナントのリスト取得に使用する構造体です。
+type TenantListCaller struct {
+	service *Service
+	limit   int
+	offset  int
+	since   int
+	until   int
+}
+
+// Limit はリストの要素数の最大値を設定します(1-100)
+func (c *TenantListCaller) Limit(limit int) *TenantListCaller {
+	c.limit = limit
+	return c
+}
+
+// Offset は取得するリストの先頭要素のインデックスのオフセットを設定します
+func (c *TenantListCaller) Offset(offset int) *TenantListCaller {
+	c.offset = offset
+	return c
+}
+
+// Since はここに指定したタイムスタンプ以降に作成されたデータを取得します
+func (c *TenantListCaller) Since(since time.Time) *TenantListCaller {
+	c.since = int(since.Unix())
+	return c
+}
+
+// Until はここに指定したタイムスタンプ以前に作成されたデータを取得します
+func (c *TenantListCaller) Until(until time.Time) *TenantListCaller {
+	c.until = int(until.Unix())
+	return c
+}
+
+// Do は指定されたクエリーを元にテナントのリストを配列で取得します。
+func (c *TenantListCaller) Do() ([]*TenantResponse, bool, error) {
+	return c.DoContext(context.Background())
+}
+
+// DoContext はcontext.Contextを指定してテナントのリストを配列で取得します。
+func (c *TenantListCaller) DoContext(ctx context.Context) ([]*TenantResponse, bool, error) {
+	var hasMore bool
+	result, err := c.service.call(ctx, "tenant.list", "", func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.queryList(ctx, "/tenants", c.limit, c.offset, c.since, c.until)
+		if err != nil {
+			return nil, err
+		}
+		raw := &listResponseParser{}
+		err = json.Unmarshal(body, raw)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]*TenantResponse, len(raw.Data))
+		for i, rawTenant := range raw.Data {
+			tenant := &TenantResponse{}
+			json.Unmarshal(rawTenant, tenant)
+			tenant.service = c.service
+			result[i] = tenant
+		}
+		hasMore = raw.HasMore
+		return result, nil
+	})
+	list, _ := result.([]*TenantResponse)
+	return list, hasMore, err
+}
+
+// Iter はテナントのリストを順に取得するイテレータを返します。次のページは自動的に取得されます。
+func (c *TenantListCaller) Iter() *TenantIter {
+	return c.IterContext(context.Background())
+}
+
+// IterContext はcontext.Contextを指定してイテレータを返します。ctxがキャンセルされると取得を中断します。
+func (c *TenantListCaller) IterContext(ctx context.Context) *TenantIter {
+	caller := *c
+	return &TenantIter{newListIter(ctx, c.offset, c.limit, func(ctx context.Context, offset, limit int) ([]interface{}, bool, error) {
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	})}
+}
+
+// TenantIter はテナントのリストを順に取得するイテレータです。
+type TenantIter struct {
+	listIter
+}
+
+// Max は取得する要素数の上限を設定します。0の場合は上限なしです。
+func (it *TenantIter) Max(n int) *TenantIter {
+	it.max = n
+	return it
+}
+
+// Value は現在の要素を返します。
+func (it *TenantIter) Value() *TenantResponse {
+	value, _ := it.current.(*TenantResponse)
+	return value
+}
+
+// ReviewedBrand はテナントのカードブランドごとの審査状況です。
+type ReviewedBrand struct {
+	Brand         string    // カードブランド名
+	Status        string    // 審査状況("passed", "in_review"など)
+	AvailableDate time.Time // 利用可能になった日時
+}
+
+// TenantResponse はTenantService.Retrieveなどで返されるテナントを表す構造体です
+type TenantResponse struct {
+	ID                    string            // テナントID
+	LiveMode              bool              // 本番環境かどうか
+	CreatedAt             time.Time         // このテナント作成時のタイムスタンプ
+	Name                  string            // テナント名
+	PlatformFeeRate       string            // プラットフォーム利用料率(%)
+	PayjpFeeIncluded      bool              // 決済手数料をプラットフォーム利用料に含めるかどうか
+	MinimumTransferAmount int               // 最低入金額
+	BankCode              string            // 金融機関コード
+	BankBranchCode        string            // 支店コード
+	BankAccountType       string            // 預金種別
+	BankAccountNumber     string            // 口座番号
+	BankAccountHolderName string            // 口座名義
+	BankAccountStatus     string            // 入金先口座の状態
+	CurrenciesSupported   []string          // 対応通貨
+	DefaultCurrency       string            // デフォルトの通貨
+	ReviewedBrands        []*ReviewedBrand  // カードブランドごとの審査状況
+	Metadata              map[string]string // メタデータ
+
+	service *Service
+}
+
+type reviewedBrandParser struct {
+	Brand              string `json:"brand"`
+	Status             string `json:"status"`
+	AvailableDateEpoch int    `json:"available_date"`
+}
+
+type tenantResponseParser struct {
+	BankAccountHolderName string                 `json:"bank_account_holder_name"`
+	BankAccountNumber     string                 `json:"bank_account_number"`
+	BankAccountStatus     string                 `json:"bank_account_status"`
+	BankAccountType       string                 `json:"bank_account_type"`
+	BankBranchCode        string                 `json:"bank_branch_code"`
+	BankCode              string                 `json:"bank_code"`
+	CreatedEpoch          int                    `json:"created"`
+	CurrenciesSupported   []string               `json:"currencies_supported"`
+	DefaultCurrency       string                 `json:"default_currency"`
+	ID                    string                 `json:"id"`
+	LiveMode              bool                   `json:"livemode"`
+	MinimumTransferAmount int                    `json:"minimum_transfer_amount"`
+	Name                  string                 `json:"name"`
+	Object                string                 `json:"object"`
+	PayjpFeeIncluded      bool                   `json:"payjp_fee_included"`
+	PlatformFeeRate       string                 `json:"platform_fee_rate"`
+	ReviewedBrands        []*reviewedBrandParser `json:"reviewed_brands"`
+	Metadata              map[string]string      `json:"metadata"`
+}
+
+// Update はテナント情報を更新します。
+func (t *TenantResponse) Update(tenant Tenant) error {
+	return t.UpdateWithContext(context.Background(), tenant)
+}
+
+// UpdateWithContext はcontext.Contextを指定してテナント情報を更新します。
+func (t *TenantResponse) UpdateWithContext(ctx context.Context, tenant Tenant) error {
+	_, err := t.service.call(ctx, "tenant.update", t.ID, func(ctx context.Context) (interface{}, error) {
+		body, err := t.service.Tenant.update(ctx, t.ID, tenant)
+		if err != nil {
+			return nil, err
+		}
+		return t, json.Unmarshal(body, t)
+	})
+	return err
+}
+
+// Delete はテナントを削除します。
+func (t *TenantResponse) Delete() error {
+	return t.service.Tenant.Delete(t.ID)
+}
+
+// DeleteWithContext はcontext.Contextを指定してテナントを削除します。
+func (t *TenantResponse) DeleteWithContext(ctx context.Context) error {
+	return t.service.Tenant.DeleteWithContext(ctx, t.ID)
+}
+
+// CreateApplicationURL はテナントの審査申請URLを作成します。
+func (t *TenantResponse) CreateApplicationURL() (*ApplicationURLResponse, error) {
+	return t.service.Tenant.CreateApplicationURL(t.ID)
+}
+
+// CreateApplicationURLWithContext はcontext.Contextを指定して審査申請URLを作成します。
+func (t *TenantResponse) CreateApplicationURLWithContext(ctx context.Context) (*ApplicationURLResponse, error) {
+	return t.service.Tenant.CreateApplicationURLWithContext(ctx, t.ID)
+}
+
+// UnmarshalJSON はJSONパース用の内部APIです。
+func (t *TenantResponse) UnmarshalJSON(b []byte) error {
+	raw := tenantResponseParser{}
+	err := json.Unmarshal(b, &raw)
+	if err == nil && raw.Object == "tenant" {
+		t.BankAccountHolderName = raw.BankAccountHolderName
+		t.BankAccountNumber = raw.BankAccountNumber
+		t.BankAccountStatus = raw.BankAccountStatus
+		t.BankAccountType = raw.BankAccountType
+		t.BankBranchCode = raw.BankBranchCode
+		t.BankCode = raw.BankCode
+		t.CreatedAt = time.Unix(int64(raw.CreatedEpoch), 0)
+		t.CurrenciesSupported = raw.CurrenciesSupported
+		t.DefaultCurrency = raw.DefaultCurrency
+		t.ID = raw.ID
+		t.LiveMode = raw.LiveMode
+		t.MinimumTransferAmount = raw.MinimumTransferAmount
+		t.Name = raw.Name
+		t.PayjpFeeIncluded = raw.PayjpFeeIncluded
+		t.PlatformFeeRate = raw.PlatformFeeRate
+		t.ReviewedBrands = make([]*ReviewedBrand, len(raw.ReviewedBrands))
+		for i, brand := range raw.ReviewedBrands {
+			t.ReviewedBrands[i] = &ReviewedBrand{
+				Brand:         brand.Brand,
+				Status:        brand.Status,
+				AvailableDate: time.Unix(int64(brand.AvailableDateEpoch), 0),
+			}
+		}
+		t.Metadata = raw.Metadata
+		return nil
+	}
+	rawError := errorResponse{}
+	err = json.Unmarshal(b, &rawError)
+	if err == nil && rawError.Error.Status != 0 {
+		return &rawError.Error
+	}
+
+	return nil
+}
+
+// ApplicationURLResponse はテナントの審査申請URLを表す構造体です
+type ApplicationURLResponse struct {
+	URL       string    // 審査申請URL
+	ExpiresAt time.Time // URLの有効期限
+}
+
+type applicationURLResponseParser struct {
+	Expires int    `json:"expires"`
+	Object  string `json:"object"`
+	URL     string `json:"url"`
+}
+
+// UnmarshalJSON はJSONパース用の内部APIです。
+func (a *ApplicationURLResponse) UnmarshalJSON(b []byte) error {
+	raw := applicationURLResponseParser{}
+	err := json.Unmarshal(b, &raw)
+	if err == nil && raw.Object == "application_url" {
+		a.URL = raw.URL
+		a.ExpiresAt = time.Unix(int64(raw.Expires), 0)
+		return nil
+	}
+	rawError := errorResponse{}
+	err = json.Unmarshal(b, &rawError)
+	if err == nil && rawError.Error.Status != 0 {
+		return &rawError.Error
+	}
+
+	return nil
+}
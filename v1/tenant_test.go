@@ -0,0 +1,177 @@
+package payjp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+var tenantResponseJSON = []byte(`
+{
+  "bank_account_holder_name": "ﾍﾟｲ ｼﾞｪｲﾋﾟｰ",
+  "bank_account_number": "1234567",
+  "bank_account_status": "pending",
+  "bank_account_type": "普通",
+  "bank_branch_code": "123",
+  "bank_code": "0001",
+  "created": 1583222082,
+  "currencies_supported": [
+    "jpy"
+  ],
+  "default_currency": "jpy",
+  "id": "ten_121673955bd7aa144de5a8f6c262",
+  "livemode": false,
+  "metadata": null,
+  "minimum_transfer_amount": 5000,
+  "name": "test",
+  "object": "tenant",
+  "payjp_fee_included": false,
+  "platform_fee_rate": "10.15",
+  "reviewed_brands": [
+    {
+      "available_date": 1583222082,
+      "brand": "Visa",
+      "status": "passed"
+    }
+  ]
+}
+`)
+
+var tenantListResponseJSON = []byte(`
+{
+  "count": 1,
+  "data": [` + string(tenantResponseJSON) + `],
+  "has_more": false,
+  "object": "list",
+  "url": "/v1/tenants"
+}
+`)
+
+var applicationURLResponseJSON = []byte(`
+{
+  "expires": 1583308482,
+  "object": "application_url",
+  "url": "https://pay.jp/_/applications/start/c24368137e384aa9xxxxxxxxxxxxxxxx"
+}
+`)
+
+func TestParseTenantResponseJSON(t *testing.T) {
+	tenant := &TenantResponse{}
+	err := json.Unmarshal(tenantResponseJSON, tenant)
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+	if tenant.ID != "ten_121673955bd7aa144de5a8f6c262" {
+		t.Errorf("tenant.ID should be 'ten_121673955bd7aa144de5a8f6c262', but '%s'", tenant.ID)
+	}
+	if tenant.PlatformFeeRate != "10.15" || tenant.MinimumTransferAmount != 5000 || tenant.BankAccountType != "普通" {
+		t.Errorf("parse error: %#v", tenant)
+	}
+	if len(tenant.ReviewedBrands) != 1 || tenant.ReviewedBrands[0].Brand != "Visa" || tenant.ReviewedBrands[0].AvailableDate.Unix() != 1583222082 {
+		t.Errorf("parse error: tenant.ReviewedBrands %v", tenant.ReviewedBrands)
+	}
+}
+
+func TestTenantCreate(t *testing.T) {
+	server, form := newFormRecordingServer(tenantResponseJSON)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+	tenant, err := service.Tenant.Create(Tenant{
+		ID:                    String("ten_121673955bd7aa144de5a8f6c262"),
+		Name:                  String("test"),
+		PlatformFeeRate:       String("10.15"),
+		PayjpFeeIncluded:      Bool(false),
+		MinimumTransferAmount: Int(5000),
+		BankAccountType:       String("普通"),
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if tenant.Name != "test" {
+		t.Errorf("tenant.Name should be 'test', but '%s'", tenant.Name)
+	}
+	expected := "bank_account_type=%E6%99%AE%E9%80%9A&id=ten_121673955bd7aa144de5a8f6c262&minimum_transfer_amount=5000&name=test&payjp_fee_included=false&platform_fee_rate=10.15"
+	if form.Encode() != expected {
+		t.Errorf("form is wrong: %s", form.Encode())
+	}
+
+	_, err = service.Tenant.Create(Tenant{Name: String("test")})
+	if err == nil || !strings.Contains(err.Error(), "PlatformFeeRate is required") {
+		t.Errorf("err should report missing PlatformFeeRate, but %v", err)
+	}
+}
+
+func TestTenantUpdate(t *testing.T) {
+	server, form := newFormRecordingServer(tenantResponseJSON)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+	tenant, err := service.Tenant.Retrieve("ten_121673955bd7aa144de5a8f6c262")
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	err = tenant.Update(Tenant{Name: String("updated"), Metadata: map[string]string{"key": "value"}})
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+	if form.Encode() != "metadata%5Bkey%5D=value&name=updated" {
+		t.Errorf("form is wrong: %s", form.Encode())
+	}
+}
+
+func TestTenantDelete(t *testing.T) {
+	mock, transport := NewMockClient(200, []byte(`{"deleted": true, "id": "ten_121673955bd7aa144de5a8f6c262", "livemode": false}`))
+	service := New("api-key", mock)
+	err := service.Tenant.Delete("ten_121673955bd7aa144de5a8f6c262")
+	if transport.URL != "https://api.pay.jp/v1/tenants/ten_121673955bd7aa144de5a8f6c262" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if transport.Method != "DELETE" {
+		t.Errorf("Method should be DELETE, but %s", transport.Method)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+}
+
+func TestTenantList(t *testing.T) {
+	mock, transport := NewMockClient(200, tenantListResponseJSON)
+	service := New("api-key", mock)
+	tenants, hasMore, err := service.Tenant.List().
+		Limit(10).
+		Offset(15).
+		Since(time.Unix(1455328095, 0)).
+		Until(time.Unix(1455500895, 0)).Do()
+	if transport.URL != "https://api.pay.jp/v1/tenants?limit=10&offset=15&since=1455328095&until=1455500895" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	if hasMore {
+		t.Error("parse error: hasMore")
+	}
+	if len(tenants) != 1 || tenants[0].Name != "test" {
+		t.Errorf("parse error: tenants %v", tenants)
+	}
+}
+
+func TestTenantCreateApplicationURL(t *testing.T) {
+	mock, transport := NewMockClient(200, applicationURLResponseJSON)
+	service := New("api-key", mock)
+	applicationURL, err := service.Tenant.CreateApplicationURL("ten_121673955bd7aa144de5a8f6c262")
+	if transport.URL != "https://api.pay.jp/v1/tenants/ten_121673955bd7aa144de5a8f6c262/application_urls" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if transport.Method != "POST" {
+		t.Errorf("Method should be POST, but %s", transport.Method)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	if !strings.HasPrefix(applicationURL.URL, "https://pay.jp/_/applications/start/") || applicationURL.ExpiresAt.Unix() != 1583308482 {
+		t.Errorf("parse error: %#v", applicationURL)
+	}
+}
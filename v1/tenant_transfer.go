@@ -0,0 +1,285 @@
+package payjp
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+// TenantTransferService はPAY.JP Platformのテナントへの入金に関するサービスです。
+//
+// テナントの売上は、テナントごとの入金(テナントトランスファー)として集計されます。
+type TenantTransferService struct {
+	service *Service
+}
+
+func newTenantTransferService(service *Service) *TenantTransferService {
+	return &TenantTransferService{
+		service: service,
+	}
+}
+
+// Retrieve はテナントへの入金情報を取得します。
+func (t TenantTransferService) Retrieve(transferID string) (*TenantTransferResponse, error) {
+	return t.RetrieveWithContext(context.Background(), transferID)
+}
+
+// RetrieveWithContext はcontext.Contextを指定してテナントへの入金情報を取得します。
+func (t TenantTransferService) RetrieveWithContext(ctx context.Context, transferID string) (*TenantTransferResponse, error) {
+	result, err := t.service.call(ctx, "tenant_transfer.retrieve", transferID, func(ctx context.Context) (interface{}, error) {
+		body, err := t.service.retrieve(ctx, "/tenant_transfers/"+transferID)
+		if err != nil {
+			return nil, err
+		}
+		result := &TenantTransferResponse{}
+		err = json.Unmarshal(body, result)
+		if err != nil {
+			return nil, err
+		}
+		result.service = t.service
+		return result, nil
+	})
+	response, _ := result.(*TenantTransferResponse)
+	return response, err
+}
+
+// List はテナントへの入金リストを取得します。リストは、直近で生成された順番に取得されます。
+func (t TenantTransferService) List() *TenantTransferListCaller {
+	return &TenantTransferListCaller{
+		status:  noTransferStatus,
+		service: t.service,
+	}
+}
+
+// TenantTransferListCaller はテナントへの入金のリスト取得に使用する構造体です。
+type TenantTransferListCaller struct {
+	service            *Service
+	limit              int
+	offset             int
+	since              int
+	until              int
+	sinceScheduledDate int
+	untilScheduledDate int
+	status             TransferStatus
+	tenantID           string
+}
+
+// Limit はリストの要素数の最大値を設定します(1-100)
+func (c *TenantTransferListCaller) Limit(limit int) *TenantTransferListCaller {
+	c.limit = limit
+	return c
+}
+
+// Offset は取得するリストの先頭要素のインデックスのオフセットを設定します
+func (c *TenantTransferListCaller) Offset(offset int) *TenantTransferListCaller {
+	c.offset = offset
+	return c
+}
+
+// SinceScheduledDate は入金予定日がここに指定したタイムスタンプ以降のデータのみ取得します
+func (c *TenantTransferListCaller) SinceScheduledDate(sinceScheduledDate time.Time) *TenantTransferListCaller {
+	c.sinceScheduledDate = int(sinceScheduledDate.Unix())
+	return c
+}
+
+// UntilScheduledDate は入金予定日がここに指定したタイムスタンプ以前のデータのみ取得します
+func (c *TenantTransferListCaller) UntilScheduledDate(untilScheduledDate time.Time) *TenantTransferListCaller {
+	c.untilScheduledDate = int(untilScheduledDate.Unix())
+	return c
+}
+
+// Since はここに指定したタイムスタンプ以降に作成されたデータを取得します
+func (c *TenantTransferListCaller) Since(since time.Time) *TenantTransferListCaller {
+	c.since = int(since.Unix())
+	return c
+}
+
+// Until はここに指定したタイムスタンプ以前に作成されたデータを取得します
+func (c *TenantTransferListCaller) Until(until time.Time) *TenantTransferListCaller {
+	c.until = int(until.Unix())
+	return c
+}
+
+// Status はここで指定されたステータスのデータを取得します
+func (c *TenantTransferListCaller) Status(status TransferStatus) *TenantTransferListCaller {
+	c.status = status
+	return c
+}
+
+// TenantID を指定すると、指定したテナントへの入金のみを取得します
+func (c *TenantTransferListCaller) TenantID(id string) *TenantTransferListCaller {
+	c.tenantID = id
+	return c
+}
+
+// Do は指定されたクエリーを元にテナントへの入金のリストを配列で取得します。
+func (c *TenantTransferListCaller) Do() ([]*TenantTransferResponse, bool, error) {
+	return c.DoContext(context.Background())
+}
+
+// DoContext はcontext.Contextを指定してテナントへの入金のリストを配列で取得します。
+func (c *TenantTransferListCaller) DoContext(ctx context.Context) ([]*TenantTransferResponse, bool, error) {
+	var hasMore bool
+	result, err := c.service.call(ctx, "tenant_transfer.list", "", func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.queryTransferList(ctx, "/tenant_transfers", c.limit, c.offset, c.since, c.until, c.sinceScheduledDate, c.untilScheduledDate, func(values *url.Values) bool {
+			result := false
+			if c.status != noTransferStatus {
+				values.Add("status", c.status.status().(string))
+				result = true
+			}
+			if c.tenantID != "" {
+				values.Add("tenant", c.tenantID)
+				result = true
+			}
+			return result
+		})
+		if err != nil {
+			return nil, err
+		}
+		raw := &listResponseParser{}
+		err = json.Unmarshal(body, raw)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]*TenantTransferResponse, len(raw.Data))
+		for i, rawTransfer := range raw.Data {
+			transfer := &TenantTransferResponse{}
+			json.Unmarshal(rawTransfer, transfer)
+			transfer.service = c.service
+			result[i] = transfer
+		}
+		hasMore = raw.HasMore
+		return result, nil
+	})
+	list, _ := result.([]*TenantTransferResponse)
+	return list, hasMore, err
+}
+
+// Iter はテナントへの入金のリストを順に取得するイテレータを返します。次のページは自動的に取得されます。
+func (c *TenantTransferListCaller) Iter() *TenantTransferIter {
+	return c.IterContext(context.Background())
+}
+
+// IterContext はcontext.Contextを指定してイテレータを返します。ctxがキャンセルされると取得を中断します。
+func (c *TenantTransferListCaller) IterContext(ctx context.Context) *TenantTransferIter {
+	caller := *c
+	return &TenantTransferIter{newListIter(ctx, c.offset, c.limit, func(ctx context.Context, offset, limit int) ([]interface{}, bool, error) {
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	})}
+}
+
+// TenantTransferIter はテナントへの入金のリストを順に取得するイテレータです。
+type TenantTransferIter struct {
+	listIter
+}
+
+// Max は取得する要素数の上限を設定します。0の場合は上限なしです。
+func (it *TenantTransferIter) Max(n int) *TenantTransferIter {
+	it.max = n
+	return it
+}
+
+// Value は現在の要素を返します。
+func (it *TenantTransferIter) Value() *TenantTransferResponse {
+	value, _ := it.current.(*TenantTransferResponse)
+	return value
+}
+
+// ChargeList はテナントへの入金の内訳となる支払いのリストを取得します。リストは、直近で生成された順番に取得されます。
+func (t TenantTransferService) ChargeList(transferID string) *TransferChargeListCaller {
+	return &TransferChargeListCaller{
+		service:      t.service,
+		resourcePath: "/tenant_transfers/",
+		transferID:   transferID,
+	}
+}
+
+// TenantTransferResponse はTenantTransferService.Retrieve、TenantTransferService.Listによって返される、
+// テナントへの入金状態を示す構造体です。
+type TenantTransferResponse struct {
+	ID             string            // ten_tr_で始まる一意なオブジェクトを示す文字列
+	LiveMode       bool              // 本番環境かどうか
+	CreatedAt      time.Time         // この入金作成時のタイムスタンプ
+	TenantID       string            // 入金先のテナントID
+	Amount         int               // 入金予定額
+	CarriedBalance int               // 繰越金
+	Currency       string            // 3文字のISOコード(現状 “jpy” のみサポート)
+	Status         TransferStatus    // この入金の処理状態
+	Charges        []*ChargeResponse // この入金に含まれる支払いのリスト
+	ScheduledDate  string            // 入金予定日
+	Summary        struct {
+		ChargeCount      int // 支払い総数
+		ChargeFee        int // 支払い手数料
+		ChargeGross      int // 総売上
+		Net              int // 差引額
+		RefundAmount     int // 返金総額
+		RefundCount      int // 返金総数
+		DisputeAmount    int // チャージバックにより相殺された金額の合計
+		DisputeCount     int // チャージバック対象となったchargeの個数
+		TotalPlatformFee int // プラットフォーム利用料の総額
+	} // この入金に関する集計情報
+	TermStartAt    time.Time // 集計期間開始時のタイムスタンプ
+	TermEndAt      time.Time // 集計期間終了時のタイムスタンプ
+	TransferAmount int       // 入金額
+	TransferDate   string    // 入金日
+
+	service *Service
+}
+
+type tenantTransferResponseParser struct {
+	transferResponseParser
+	TenantID string `json:"tenant_id"`
+}
+
+// UnmarshalJSON はJSONパース用の内部APIです。
+func (t *TenantTransferResponse) UnmarshalJSON(b []byte) error {
+	raw := tenantTransferResponseParser{}
+	err := json.Unmarshal(b, &raw)
+	if err == nil && raw.Object == "tenant_transfer" {
+		t.Amount = raw.Amount
+		t.CarriedBalance = raw.CarriedBalance
+		t.CreatedAt = time.Unix(int64(raw.CreatedEpoch), 0)
+		t.Currency = raw.Currency
+		t.ID = raw.ID
+		t.LiveMode = raw.LiveMode
+		t.ScheduledDate = raw.ScheduledDate
+		t.Status = parseTransferStatus(raw.Status)
+		t.Summary.ChargeCount = raw.Summary.ChargeCount
+		t.Summary.ChargeFee = raw.Summary.ChargeFee
+		t.Summary.ChargeGross = raw.Summary.ChargeGross
+		t.Summary.Net = raw.Summary.Net
+		t.Summary.RefundAmount = raw.Summary.RefundAmount
+		t.Summary.RefundCount = raw.Summary.RefundCount
+		t.Summary.DisputeAmount = raw.Summary.DisputeAmount
+		t.Summary.DisputeCount = raw.Summary.DisputeCount
+		t.Summary.TotalPlatformFee = raw.Summary.TotalPlatformFee
+		t.TenantID = raw.TenantID
+		t.TermEndAt = time.Unix(int64(raw.TermEndEpoch), 0)
+		t.TermStartAt = time.Unix(int64(raw.TermStartEpoch), 0)
+		t.TransferAmount = raw.TransferAmount
+		t.TransferDate = raw.TransferDate
+		t.Charges = make([]*ChargeResponse, len(raw.Charges.Data))
+		for i, rawCharge := range raw.Charges.Data {
+			charge := &ChargeResponse{}
+			json.Unmarshal(rawCharge, charge)
+			t.Charges[i] = charge
+		}
+
+		return nil
+	}
+	rawError := errorResponse{}
+	err = json.Unmarshal(b, &rawError)
+	if err == nil && rawError.Error.Status != 0 {
+		return &rawError.Error
+	}
+
+	return nil
+}
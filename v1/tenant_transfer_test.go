@@ -0,0 +1,147 @@
+package payjp
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var tenantTransferJSONStr = `
+{
+  "amount": 1000,
+  "carried_balance": null,
+  "charges": ` + chargeListJSONStr + `,
+  "created": 1438354800,
+  "currency": "jpy",
+  "id": "ten_tr_23748b8c1c2fd5a8b4a6f4b4f8f1f",
+  "livemode": false,
+  "object": "tenant_transfer",
+  "scheduled_date": "2015-09-16",
+  "status": "paid",
+  "summary": {
+    "charge_count": 1,
+    "charge_fee": 0,
+    "charge_gross": 1000,
+    "net": 900,
+    "refund_amount": 0,
+    "refund_count": 0,
+    "dispute_amount": 0,
+    "dispute_count": 0,
+    "total_platform_fee": 100
+  },
+  "tenant_id": "ten_121673955bd7aa144de5a8f6c262",
+  "term_end": 1439650800,
+  "term_start": 1438354800,
+  "transfer_amount": 900,
+  "transfer_date": "2015-09-16"
+}`
+
+var tenantTransferResponseJSON = []byte(tenantTransferJSONStr)
+
+var tenantTransferListResponseJSON = []byte(`
+{
+  "count": 1,
+  "data": [` + tenantTransferJSONStr + `],
+  "has_more": false,
+  "object": "list",
+  "url": "/v1/tenant_transfers"
+}`)
+
+func TestParseTenantTransferResponseJSON(t *testing.T) {
+	transfer := &TenantTransferResponse{}
+	err := json.Unmarshal(tenantTransferResponseJSON, transfer)
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+	if transfer.ID != "ten_tr_23748b8c1c2fd5a8b4a6f4b4f8f1f" {
+		t.Errorf("transfer.ID is wrong: %s", transfer.ID)
+	}
+	if transfer.TenantID != "ten_121673955bd7aa144de5a8f6c262" {
+		t.Errorf("transfer.TenantID is wrong: %s", transfer.TenantID)
+	}
+	if transfer.Status != TransferPaid {
+		t.Errorf("transfer.Status should be TransferPaid, but %v", transfer.Status)
+	}
+	if transfer.Summary.TotalPlatformFee != 100 || transfer.Summary.Net != 900 || transfer.TransferAmount != 900 {
+		t.Errorf("parse error: %#v", transfer.Summary)
+	}
+	if len(transfer.Charges) != 1 || transfer.Charges[0].Amount != 1000 {
+		t.Errorf("parse error: transfer.Charges %v", transfer.Charges)
+	}
+}
+
+func TestTenantTransferRetrieve(t *testing.T) {
+	mock, transport := NewMockClient(200, tenantTransferResponseJSON)
+	service := New("api-key", mock)
+	transfer, err := service.TenantTransfer.Retrieve("ten_tr_23748b8c1c2fd5a8b4a6f4b4f8f1f")
+	if transport.URL != "https://api.pay.jp/v1/tenant_transfers/ten_tr_23748b8c1c2fd5a8b4a6f4b4f8f1f" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if transport.Method != "GET" {
+		t.Errorf("Method should be GET, but %s", transport.Method)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	if transfer.Amount != 1000 {
+		t.Errorf("transfer.Amount should be 1000, but %d", transfer.Amount)
+	}
+}
+
+func TestTenantTransferList(t *testing.T) {
+	mock, transport := NewMockClient(200, tenantTransferListResponseJSON)
+	service := New("api-key", mock)
+	transfers, hasMore, err := service.TenantTransfer.List().
+		Limit(10).
+		Offset(15).
+		Since(time.Unix(1455328095, 0)).
+		Until(time.Unix(1455500895, 0)).
+		Status(TransferPaid).
+		TenantID("ten_121673955bd7aa144de5a8f6c262").Do()
+	if transport.URL != "https://api.pay.jp/v1/tenant_transfers?limit=10&offset=15&since=1455328095&status=paid&tenant=ten_121673955bd7aa144de5a8f6c262&until=1455500895" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	if hasMore {
+		t.Error("parse error: hasMore")
+	}
+	if len(transfers) != 1 || transfers[0].TenantID != "ten_121673955bd7aa144de5a8f6c262" {
+		t.Errorf("parse error: transfers %v", transfers)
+	}
+}
+
+func TestTenantTransferListScheduledDate(t *testing.T) {
+	mock, transport := NewMockClient(200, tenantTransferListResponseJSON)
+	service := New("api-key", mock)
+	_, _, err := service.TenantTransfer.List().
+		SinceScheduledDate(time.Unix(1455328095, 0)).
+		UntilScheduledDate(time.Unix(1455500895, 0)).Do()
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+	if transport.URL != "https://api.pay.jp/v1/tenant_transfers?since_scheduled_date=1455328095&until_scheduled_date=1455500895" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+}
+
+func TestTenantTransferChargeList(t *testing.T) {
+	mock, transport := NewMockClient(200, transferChargeListResponseJSON)
+	service := New("api-key", mock)
+	charges, _, err := service.TenantTransfer.ChargeList("ten_tr_23748b8c1c2fd5a8b4a6f4b4f8f1f").
+		Limit(10).
+		CustomerID("cus_b92b879e60f62b532d6756ae12af").Do()
+	if transport.URL != "https://api.pay.jp/v1/tenant_transfers/ten_tr_23748b8c1c2fd5a8b4a6f4b4f8f1f/charges?customer=cus_b92b879e60f62b532d6756ae12af&limit=10" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	if len(charges) != 1 {
+		t.Errorf("parse error: charges %v", charges)
+	}
+}
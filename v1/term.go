@@ -0,0 +1,223 @@
+package payjp
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// TermService は集計区間に関するサービスです。
+//
+// 売上は集計区間ごとに締められ、取引明細(Statement)と残高(Balance)が作成されます。
+type TermService struct {
+	service *Service
+}
+
+func newTermService(service *Service) *TermService {
+	return &TermService{
+		service: service,
+	}
+}
+
+// Retrieve は集計区間を取得します。
+func (t TermService) Retrieve(id string) (*TermResponse, error) {
+	return t.RetrieveWithContext(context.Background(), id)
+}
+
+// RetrieveWithContext はcontext.Contextを指定して集計区間を取得します。
+func (t TermService) RetrieveWithContext(ctx context.Context, id string) (*TermResponse, error) {
+	result, err := t.service.call(ctx, "term.retrieve", id, func(ctx context.Context) (interface{}, error) {
+		body, err := t.service.retrieve(ctx, "/terms/"+id)
+		if err != nil {
+			return nil, err
+		}
+		result := &TermResponse{}
+		err = json.Unmarshal(body, result)
+		if err != nil {
+			return nil, err
+		}
+		result.service = t.service
+		return result, nil
+	})
+	response, _ := result.(*TermResponse)
+	return response, err
+}
+
+// List は集計区間のリストを取得します。リストは、開始日時の新しい順番に取得されます。
+func (t TermService) List() *TermListCaller {
+	return &TermListCaller{
+		service: t.service,
+	}
+}
+
+// TermListCaller は集計区間のリスト取得に使用する構造体です。
+type TermListCaller struct {
+	service      *Service
+	limit        int
+	offset       int
+	sinceStartAt int
+	untilStartAt int
+}
+
+// Limit はリストの要素数の最大値を設定します(1-100)
+func (c *TermListCaller) Limit(limit int) *TermListCaller {
+	c.limit = limit
+	return c
+}
+
+// Offset は取得するリストの先頭要素のインデックスのオフセットを設定します
+func (c *TermListCaller) Offset(offset int) *TermListCaller {
+	c.offset = offset
+	return c
+}
+
+// SinceStartAt は開始日時がここに指定したタイムスタンプ以降の集計区間のみ取得します
+func (c *TermListCaller) SinceStartAt(sinceStartAt time.Time) *TermListCaller {
+	c.sinceStartAt = int(sinceStartAt.Unix())
+	return c
+}
+
+// UntilStartAt は開始日時がここに指定したタイムスタンプ以前の集計区間のみ取得します
+func (c *TermListCaller) UntilStartAt(untilStartAt time.Time) *TermListCaller {
+	c.untilStartAt = int(untilStartAt.Unix())
+	return c
+}
+
+// Do は指定されたクエリーを元に集計区間のリストを配列で取得します。
+func (c *TermListCaller) Do() ([]*TermResponse, bool, error) {
+	return c.DoContext(context.Background())
+}
+
+// DoContext はcontext.Contextを指定して集計区間のリストを配列で取得します。
+func (c *TermListCaller) DoContext(ctx context.Context) ([]*TermResponse, bool, error) {
+	var hasMore bool
+	result, err := c.service.call(ctx, "term.list", "", func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.queryList(ctx, "/terms", c.limit, c.offset, 0, 0, func(values *url.Values) bool {
+			result := false
+			if c.sinceStartAt != 0 {
+				values.Add("since_start_at", strconv.Itoa(c.sinceStartAt))
+				result = true
+			}
+			if c.untilStartAt != 0 {
+				values.Add("until_start_at", strconv.Itoa(c.untilStartAt))
+				result = true
+			}
+			return result
+		})
+		if err != nil {
+			return nil, err
+		}
+		raw := &listResponseParser{}
+		err = json.Unmarshal(body, raw)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]*TermResponse, len(raw.Data))
+		for i, rawTerm := range raw.Data {
+			term := &TermResponse{}
+			json.Unmarshal(rawTerm, term)
+			term.service = c.service
+			result[i] = term
+		}
+		hasMore = raw.HasMore
+		return result, nil
+	})
+	list, _ := result.([]*TermResponse)
+	return list, hasMore, err
+}
+
+// Iter は集計区間のリストを順に取得するイテレータを返します。次のページは自動的に取得されます。
+func (c *TermListCaller) Iter() *TermIter {
+	return c.IterContext(context.Background())
+}
+
+// IterContext はcontext.Contextを指定してイテレータを返します。ctxがキャンセルされると取得を中断します。
+func (c *TermListCaller) IterContext(ctx context.Context) *TermIter {
+	caller := *c
+	return &TermIter{newListIter(ctx, c.offset, c.limit, func(ctx context.Context, offset, limit int) ([]interface{}, bool, error) {
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	})}
+}
+
+// TermIter は集計区間のリストを順に取得するイテレータです。
+type TermIter struct {
+	listIter
+}
+
+// Max は取得する要素数の上限を設定します。0の場合は上限なしです。
+func (it *TermIter) Max(n int) *TermIter {
+	it.max = n
+	return it
+}
+
+// Value は現在の要素を返します。
+func (it *TermIter) Value() *TermResponse {
+	value, _ := it.current.(*TermResponse)
+	return value
+}
+
+// TermResponse はTermService.Retrieve、TermService.Listで返される、売上を集計する区間(集計区間)を表す構造体です
+type TermResponse struct {
+	ID           string    // tm_で始まる一意なオブジェクトを示す文字列
+	LiveMode     bool      // 本番環境かどうか
+	StartAt      time.Time // 集計区間の開始時のタイムスタンプ
+	EndAt        time.Time // 集計区間の終了時のタイムスタンプ(集計中の区間はゼロ値)
+	Closed       bool      // 集計が確定しているかどうか
+	ChargeCount  int       // 区間内の支払い数
+	RefundCount  int       // 区間内の返金数
+	DisputeCount int       // 区間内のチャージバック数
+
+	service *Service
+}
+
+type termResponseParser struct {
+	ChargeCount  int    `json:"charge_count"`
+	Closed       bool   `json:"closed"`
+	DisputeCount int    `json:"dispute_count"`
+	EndAtEpoch   int    `json:"end_at"`
+	ID           string `json:"id"`
+	LiveMode     bool   `json:"livemode"`
+	Object       string `json:"object"`
+	RefundCount  int    `json:"refund_count"`
+	StartAtEpoch int    `json:"start_at"`
+}
+
+// Statements はこの集計区間に含まれる取引明細のリストを取得します。
+func (t *TermResponse) Statements() *StatementListCaller {
+	return t.service.Statement.List().TermID(t.ID)
+}
+
+// UnmarshalJSON はJSONパース用の内部APIです。
+func (t *TermResponse) UnmarshalJSON(b []byte) error {
+	raw := termResponseParser{}
+	err := json.Unmarshal(b, &raw)
+	if err == nil && raw.Object == "term" {
+		t.ChargeCount = raw.ChargeCount
+		t.Closed = raw.Closed
+		t.DisputeCount = raw.DisputeCount
+		if raw.EndAtEpoch != 0 {
+			t.EndAt = time.Unix(int64(raw.EndAtEpoch), 0)
+		}
+		t.ID = raw.ID
+		t.LiveMode = raw.LiveMode
+		t.RefundCount = raw.RefundCount
+		t.StartAt = time.Unix(int64(raw.StartAtEpoch), 0)
+		return nil
+	}
+	rawError := errorResponse{}
+	err = json.Unmarshal(b, &rawError)
+	if err == nil && rawError.Error.Status != 0 {
+		return &rawError.Error
+	}
+
+	return nil
+}
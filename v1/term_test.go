@@ -0,0 +1,105 @@
+package payjp
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var termResponseJSON = []byte(`
+{
+  "charge_count": 158,
+  "closed": true,
+  "dispute_count": 0,
+  "end_at": 1439650800,
+  "id": "tm_b92b879e60f62b532d6756ae12af",
+  "livemode": false,
+  "object": "term",
+  "refund_count": 25,
+  "start_at": 1438354800
+}
+`)
+
+var termListResponseJSON = []byte(`
+{
+  "count": 2,
+  "data": [
+    {
+      "charge_count": 3,
+      "closed": false,
+      "dispute_count": 0,
+      "end_at": null,
+      "id": "tm_c03c980f71a73c643e7867bf23b0",
+      "livemode": false,
+      "object": "term",
+      "refund_count": 0,
+      "start_at": 1439650800
+    },
+    ` + string(termResponseJSON) + `
+  ],
+  "has_more": false,
+  "object": "list",
+  "url": "/v1/terms"
+}
+`)
+
+func TestParseTermResponseJSON(t *testing.T) {
+	term := &TermResponse{}
+	err := json.Unmarshal(termResponseJSON, term)
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+	if term.ID != "tm_b92b879e60f62b532d6756ae12af" || !term.Closed || term.ChargeCount != 158 || term.RefundCount != 25 {
+		t.Errorf("parse error: %#v", term)
+	}
+	if term.StartAt.Unix() != 1438354800 || term.EndAt.Unix() != 1439650800 {
+		t.Errorf("parse error: term.StartAt %v, term.EndAt %v", term.StartAt, term.EndAt)
+	}
+}
+
+func TestTermRetrieve(t *testing.T) {
+	mock, transport := NewMockClient(200, termResponseJSON)
+	transport.AddResponse(200, statementListResponseJSON)
+	service := New("api-key", mock)
+	term, err := service.Term.Retrieve("tm_b92b879e60f62b532d6756ae12af")
+	if transport.URL != "https://api.pay.jp/v1/terms/tm_b92b879e60f62b532d6756ae12af" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if transport.Method != "GET" {
+		t.Errorf("Method should be GET, but %s", transport.Method)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	statements, _, err := term.Statements().Type("sales").Do()
+	if transport.URL != "https://api.pay.jp/v1/statements?term=tm_b92b879e60f62b532d6756ae12af&type=sales" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if err != nil || len(statements) != 1 {
+		t.Errorf("statements should be listed, but %v, %v", statements, err)
+	}
+}
+
+func TestTermList(t *testing.T) {
+	mock, transport := NewMockClient(200, termListResponseJSON)
+	service := New("api-key", mock)
+	terms, hasMore, err := service.Term.List().
+		Limit(10).
+		Offset(15).
+		SinceStartAt(time.Unix(1438354800, 0)).
+		UntilStartAt(time.Unix(1439650800, 0)).Do()
+	if transport.URL != "https://api.pay.jp/v1/terms?limit=10&offset=15&since_start_at=1438354800&until_start_at=1439650800" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	if hasMore {
+		t.Error("parse error: hasMore")
+	}
+	if len(terms) != 2 || terms[0].Closed || !terms[0].EndAt.IsZero() || terms[1].ID != "tm_b92b879e60f62b532d6756ae12af" {
+		t.Errorf("parse error: terms %v", terms)
+	}
+}
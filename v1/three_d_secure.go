@@ -0,0 +1,315 @@
+package payjp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ThreeDSecureStatus は3Dセキュアの認証状態を示すステータスです
+type ThreeDSecureStatus int
+
+const (
+	noThreeDSecureStatus ThreeDSecureStatus = iota
+	// ThreeDSecureUnverified は3Dセキュアの認証が未実施であることを表す定数
+	ThreeDSecureUnverified
+	// ThreeDSecureVerified は3Dセキュアの認証に成功したことを表す定数
+	ThreeDSecureVerified
+	// ThreeDSecureAttempted は3Dセキュアの認証を試行した(カード発行会社が未対応など)ことを表す定数
+	ThreeDSecureAttempted
+	// ThreeDSecureNotNeeded は3Dセキュアの認証が不要と判断されたことを表す定数
+	ThreeDSecureNotNeeded
+	// ThreeDSecureFailed は3Dセキュアの認証に失敗したことを表す定数
+	ThreeDSecureFailed
+	// ThreeDSecureError は3Dセキュアの認証処理でエラーが発生したことを表す定数
+	ThreeDSecureError
+)
+
+func parseThreeDSecureStatus(status string) ThreeDSecureStatus {
+	switch status {
+	case "unverified":
+		return ThreeDSecureUnverified
+	case "verified":
+		return ThreeDSecureVerified
+	case "attempted":
+		return ThreeDSecureAttempted
+	case "not_needed":
+		return ThreeDSecureNotNeeded
+	case "failed":
+		return ThreeDSecureFailed
+	case "error":
+		return ThreeDSecureError
+	}
+	return noThreeDSecureStatus
+}
+
+// ThreeDSecureRequestState は3Dセキュアリクエストの進行状態を示すステータスです
+type ThreeDSecureRequestState int
+
+const (
+	noThreeDSecureRequestState ThreeDSecureRequestState = iota
+	// ThreeDSecureRequestCreated はリクエストが作成され、認証が開始されていない状態を表す定数
+	ThreeDSecureRequestCreated
+	// ThreeDSecureRequestInProgress は顧客が認証を行っている状態を表す定数
+	ThreeDSecureRequestInProgress
+	// ThreeDSecureRequestResultReceived は認証結果を受け取った状態を表す定数
+	ThreeDSecureRequestResultReceived
+	// ThreeDSecureRequestFinished は3Dセキュアのフローが完了した状態を表す定数
+	ThreeDSecureRequestFinished
+)
+
+func parseThreeDSecureRequestState(state string) ThreeDSecureRequestState {
+	switch state {
+	case "created":
+		return ThreeDSecureRequestCreated
+	case "in_progress":
+		return ThreeDSecureRequestInProgress
+	case "result_received":
+		return ThreeDSecureRequestResultReceived
+	case "finished":
+		return ThreeDSecureRequestFinished
+	}
+	return noThreeDSecureRequestState
+}
+
+// ThreeDSecureRequestService は顧客のカードに対する3Dセキュアリクエストを扱います。
+//
+// 支払いやトークンを介さずに、顧客に登録済みのカードで3Dセキュア認証を行う場合に使用します。
+// 作成したリクエストのIDで顧客を認証画面へ遷移させ、認証結果はThreeDSecureStatusで確認できます。
+type ThreeDSecureRequestService struct {
+	service *Service
+}
+
+func newThreeDSecureRequestService(service *Service) *ThreeDSecureRequestService {
+	return &ThreeDSecureRequestService{
+		service: service,
+	}
+}
+
+// ThreeDSecureRequest は3Dセキュアリクエストの作成時に使用する構造体です
+type ThreeDSecureRequest struct {
+	ResourceID string `form:"resource_id"`         // 必須: 3Dセキュア認証を行う顧客のカードID(car_で始まる文字列)
+	TenantID   string `form:"tenant_id,omitempty"` // テナントID(PAY.JP Platformのみ)
+}
+
+// Create は顧客のカードに対する3Dセキュアリクエストを作成します。
+func (t ThreeDSecureRequestService) Create(request ThreeDSecureRequest) (*ThreeDSecureRequestResponse, error) {
+	return t.CreateWithContext(context.Background(), request)
+}
+
+// CreateWithContext はcontext.Contextを指定して3Dセキュアリクエストを作成します。
+func (t ThreeDSecureRequestService) CreateWithContext(ctx context.Context, request ThreeDSecureRequest) (*ThreeDSecureRequestResponse, error) {
+	result, err := t.service.call(ctx, "three_d_secure_request.create", "", func(ctx context.Context) (interface{}, error) {
+		if request.ResourceID == "" {
+			return nil, fmt.Errorf("payjp.ThreeDSecureRequest.Create() parameter error: ResourceID is required")
+		}
+		values, err := encodeForm(request)
+		if err != nil {
+			return nil, err
+		}
+		body, err := respToBody(t.service.request(ctx, "POST", "/three_d_secure_requests", formBody(values)))
+		if err != nil {
+			return nil, err
+		}
+		return parseThreeDSecureRequest(t.service, body)
+	})
+	response, _ := result.(*ThreeDSecureRequestResponse)
+	return response, err
+}
+
+// Retrieve は3Dセキュアリクエストを取得します。
+func (t ThreeDSecureRequestService) Retrieve(id string) (*ThreeDSecureRequestResponse, error) {
+	return t.RetrieveWithContext(context.Background(), id)
+}
+
+// RetrieveWithContext はcontext.Contextを指定して3Dセキュアリクエストを取得します。
+func (t ThreeDSecureRequestService) RetrieveWithContext(ctx context.Context, id string) (*ThreeDSecureRequestResponse, error) {
+	result, err := t.service.call(ctx, "three_d_secure_request.retrieve", id, func(ctx context.Context) (interface{}, error) {
+		body, err := t.service.retrieve(ctx, "/three_d_secure_requests/"+id)
+		if err != nil {
+			return nil, err
+		}
+		return parseThreeDSecureRequest(t.service, body)
+	})
+	response, _ := result.(*ThreeDSecureRequestResponse)
+	return response, err
+}
+
+func parseThreeDSecureRequest(service *Service, body []byte) (*ThreeDSecureRequestResponse, error) {
+	result := &ThreeDSecureRequestResponse{}
+	err := json.Unmarshal(body, result)
+	if err != nil {
+		return nil, err
+	}
+	result.service = service
+	return result, nil
+}
+
+// List は3Dセキュアリクエストのリストを取得します。リストは、直近で生成された順番に取得されます。
+func (t ThreeDSecureRequestService) List() *ThreeDSecureRequestListCaller {
+	return &ThreeDSecureRequestListCaller{
+		service: t.service,
+	}
+}
+
+// ThreeDSecureRequestListCaller は3Dセキュアリクエストのリスト取得に使用する構造体です。
+type ThreeDSecureRequestListCaller struct {
+	service *Service
+	limit   int
+	offset  int
+	since   int
+	until   int
+}
+
+// Limit はリストの要素数の最大値を設定します(1-100)
+func (c *ThreeDSecureRequestListCaller) Limit(limit int) *ThreeDSecureRequestListCaller {
+	c.limit = limit
+	return c
+}
+
+// Offset は取得するリストの先頭要素のインデックスのオフセットを設定します
+func (c *ThreeDSecureRequestListCaller) Offset(offset int) *ThreeDSecureRequestListCaller {
+	c.offset = offset
+	return c
+}
+
+// Since はここに指定したタイムスタンプ以降に作成されたデータを取得します
+func (c *ThreeDSecureRequestListCaller) Since(since time.Time) *ThreeDSecureRequestListCaller {
+	c.since = int(since.Unix())
+	return c
+}
+
+// Until はここに指定したタイムスタンプ以前に作成されたデータを取得します
+func (c *ThreeDSecureRequestListCaller) Until(until time.Time) *ThreeDSecureRequestListCaller {
+	c.until = int(until.Unix())
+	return c
+}
+
+// Do は指定されたクエリーを元に3Dセキュアリクエストのリストを配列で取得します。
+func (c *ThreeDSecureRequestListCaller) Do() ([]*ThreeDSecureRequestResponse, bool, error) {
+	return c.DoContext(context.Background())
+}
+
+// DoContext はcontext.Contextを指定して3Dセキュアリクエストのリストを配列で取得します。
+func (c *ThreeDSecureRequestListCaller) DoContext(ctx context.Context) ([]*ThreeDSecureRequestResponse, bool, error) {
+	var hasMore bool
+	result, err := c.service.call(ctx, "three_d_secure_request.list", "", func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.queryList(ctx, "/three_d_secure_requests", c.limit, c.offset, c.since, c.until)
+		if err != nil {
+			return nil, err
+		}
+		raw := &listResponseParser{}
+		err = json.Unmarshal(body, raw)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]*ThreeDSecureRequestResponse, len(raw.Data))
+		for i, rawRequest := range raw.Data {
+			request := &ThreeDSecureRequestResponse{}
+			json.Unmarshal(rawRequest, request)
+			request.service = c.service
+			result[i] = request
+		}
+		hasMore = raw.HasMore
+		return result, nil
+	})
+	list, _ := result.([]*ThreeDSecureRequestResponse)
+	return list, hasMore, err
+}
+
+// Iter は3Dセキュアリクエストのリストを順に取得するイテレータを返します。次のページは自動的に取得されます。
+func (c *ThreeDSecureRequestListCaller) Iter() *ThreeDSecureRequestIter {
+	return c.IterContext(context.Background())
+}
+
+// IterContext はcontext.Contextを指定してイテレータを返します。ctxがキャンセルされると取得を中断します。
+func (c *ThreeDSecureRequestListCaller) IterContext(ctx context.Context) *ThreeDSecureRequestIter {
+	caller := *c
+	return &ThreeDSecureRequestIter{newListIter(ctx, c.offset, c.limit, func(ctx context.Context, offset, limit int) ([]interface{}, bool, error) {
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	})}
+}
+
+// ThreeDSecureRequestIter は3Dセキュアリクエストのリストを順に取得するイテレータです。
+type ThreeDSecureRequestIter struct {
+	listIter
+}
+
+// Max は取得する要素数の上限を設定します。0の場合は上限なしです。
+func (it *ThreeDSecureRequestIter) Max(n int) *ThreeDSecureRequestIter {
+	it.max = n
+	return it
+}
+
+// Value は現在の要素を返します。
+func (it *ThreeDSecureRequestIter) Value() *ThreeDSecureRequestResponse {
+	value, _ := it.current.(*ThreeDSecureRequestResponse)
+	return value
+}
+
+// ThreeDSecureRequestResponse はThreeDSecureRequestService.Createなどで返される、3Dセキュアリクエストを表す構造体です
+type ThreeDSecureRequestResponse struct {
+	ID                 string                   // tdsr_で始まる一意なオブジェクトを示す文字列
+	LiveMode           bool                     // 本番環境かどうか
+	CreatedAt          time.Time                // このリクエスト作成時のタイムスタンプ
+	ResourceID         string                   // 3Dセキュア認証を行うカードのID
+	State              ThreeDSecureRequestState // リクエストの進行状態
+	ThreeDSecureStatus ThreeDSecureStatus       // 3Dセキュアの認証状態
+	StartedAt          time.Time                // 認証を開始した時のタイムスタンプ
+	ResultReceivedAt   time.Time                // 認証結果を受け取った時のタイムスタンプ
+	FinishedAt         time.Time                // フローが完了した時のタイムスタンプ
+	ExpiredAt          time.Time                // 認証の有効期限
+	TenantID           string                   // テナントID(PAY.JP Platformのみ)
+
+	service *Service
+}
+
+type threeDSecureRequestResponseParser struct {
+	CreatedEpoch        int    `json:"created"`
+	ExpiredEpoch        int    `json:"expired_at"`
+	FinishedEpoch       int    `json:"finished_at"`
+	ID                  string `json:"id"`
+	LiveMode            bool   `json:"livemode"`
+	Object              string `json:"object"`
+	ResourceID          string `json:"resource_id"`
+	ResultReceivedEpoch int    `json:"result_received_at"`
+	StartedEpoch        int    `json:"started_at"`
+	State               string `json:"state"`
+	TenantID            string `json:"tenant_id"`
+	ThreeDSecureStatus  string `json:"three_d_secure_status"`
+}
+
+// UnmarshalJSON はJSONパース用の内部APIです。
+func (t *ThreeDSecureRequestResponse) UnmarshalJSON(b []byte) error {
+	raw := threeDSecureRequestResponseParser{}
+	err := json.Unmarshal(b, &raw)
+	if err == nil && raw.Object == "three_d_secure_request" {
+		t.CreatedAt = time.Unix(int64(raw.CreatedEpoch), 0)
+		t.ExpiredAt = epochToTime(raw.ExpiredEpoch)
+		t.FinishedAt = epochToTime(raw.FinishedEpoch)
+		t.ID = raw.ID
+		t.LiveMode = raw.LiveMode
+		t.ResourceID = raw.ResourceID
+		t.ResultReceivedAt = epochToTime(raw.ResultReceivedEpoch)
+		t.StartedAt = epochToTime(raw.StartedEpoch)
+		t.State = parseThreeDSecureRequestState(raw.State)
+		t.TenantID = raw.TenantID
+		t.ThreeDSecureStatus = parseThreeDSecureStatus(raw.ThreeDSecureStatus)
+		return nil
+	}
+	rawError := errorResponse{}
+	err = json.Unmarshal(b, &rawError)
+	if err == nil && rawError.Error.Status != 0 {
+		return &rawError.Error
+	}
+
+	return nil
+}
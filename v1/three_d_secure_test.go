@@ -0,0 +1,113 @@
+package payjp
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var threeDSecureRequestResponseJSON = []byte(`
+{
+  "created": 1730084767,
+  "expired_at": null,
+  "finished_at": null,
+  "id": "tdsr_125192559c91c4011c1ff56f50a",
+  "livemode": true,
+  "object": "three_d_secure_request",
+  "resource_id": "car_4ec110e0700daf893160424fe03c",
+  "result_received_at": null,
+  "started_at": 1730084788,
+  "state": "in_progress",
+  "tenant_id": null,
+  "three_d_secure_status": "unverified"
+}
+`)
+
+var threeDSecureRequestListResponseJSON = []byte(`
+{
+  "count": 1,
+  "data": [` + string(threeDSecureRequestResponseJSON) + `],
+  "has_more": false,
+  "object": "list",
+  "url": "/v1/three_d_secure_requests"
+}
+`)
+
+func TestParseThreeDSecureRequestResponseJSON(t *testing.T) {
+	request := &ThreeDSecureRequestResponse{}
+	err := json.Unmarshal(threeDSecureRequestResponseJSON, request)
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+	if request.ID != "tdsr_125192559c91c4011c1ff56f50a" || request.ResourceID != "car_4ec110e0700daf893160424fe03c" {
+		t.Errorf("parse error: %#v", request)
+	}
+	if request.State != ThreeDSecureRequestInProgress || request.ThreeDSecureStatus != ThreeDSecureUnverified {
+		t.Errorf("parse error: request.State %v, request.ThreeDSecureStatus %v", request.State, request.ThreeDSecureStatus)
+	}
+	if request.StartedAt.Unix() != 1730084788 || !request.FinishedAt.IsZero() || !request.ExpiredAt.IsZero() {
+		t.Errorf("parse error: request.StartedAt %v, request.FinishedAt %v", request.StartedAt, request.FinishedAt)
+	}
+}
+
+func TestThreeDSecureRequestCreate(t *testing.T) {
+	server, form := newFormRecordingServer(threeDSecureRequestResponseJSON)
+	defer server.Close()
+	service := New("api-key", nil, Config{APIBase: server.URL})
+	request, err := service.ThreeDSecureRequest.Create(ThreeDSecureRequest{
+		ResourceID: "car_4ec110e0700daf893160424fe03c",
+		TenantID:   "ten_121673955bd7aa144de5a8f6c262",
+	})
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	if form.Encode() != "resource_id=car_4ec110e0700daf893160424fe03c&tenant_id=ten_121673955bd7aa144de5a8f6c262" {
+		t.Errorf("form is wrong: %s", form.Encode())
+	}
+	if request.ID != "tdsr_125192559c91c4011c1ff56f50a" {
+		t.Errorf("request.ID should be 'tdsr_125192559c91c4011c1ff56f50a', but '%s'", request.ID)
+	}
+
+	_, err = service.ThreeDSecureRequest.Create(ThreeDSecureRequest{})
+	if err == nil {
+		t.Error("err should not be nil when ResourceID is missing")
+	}
+}
+
+func TestThreeDSecureRequestRetrieve(t *testing.T) {
+	mock, transport := NewMockClient(200, threeDSecureRequestResponseJSON)
+	service := New("api-key", mock)
+	_, err := service.ThreeDSecureRequest.Retrieve("tdsr_125192559c91c4011c1ff56f50a")
+	if transport.URL != "https://api.pay.jp/v1/three_d_secure_requests/tdsr_125192559c91c4011c1ff56f50a" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if transport.Method != "GET" {
+		t.Errorf("Method should be GET, but %s", transport.Method)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+}
+
+func TestThreeDSecureRequestList(t *testing.T) {
+	mock, transport := NewMockClient(200, threeDSecureRequestListResponseJSON)
+	service := New("api-key", mock)
+	requests, hasMore, err := service.ThreeDSecureRequest.List().
+		Limit(10).
+		Offset(15).
+		Since(time.Unix(1455328095, 0)).
+		Until(time.Unix(1455500895, 0)).Do()
+	if transport.URL != "https://api.pay.jp/v1/three_d_secure_requests?limit=10&offset=15&since=1455328095&until=1455500895" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	if hasMore {
+		t.Error("parse error: hasMore")
+	}
+	if len(requests) != 1 || requests[0].ResourceID != "car_4ec110e0700daf893160424fe03c" {
+		t.Errorf("parse error: requests %v", requests)
+	}
+}
@@ -1,9 +1,9 @@
 package payjp
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"strings"
 	"time"
 )
@@ -49,36 +49,69 @@ func parseToken(data []byte, err error) (*TokenResponse, error) {
 // カード情報のトークン化(https://pay.jp/docs/cardtoken)をご覧ください。
 //
 // Card構造体で引数を設定しますが、Number/ExpMonth/ExpYearが必須パラメータです。
+// カード番号や有効期限などはcardvalidateパッケージで事前に検証し、誤りがあればリクエストを送信せずに*Errorを返します。
 func (t TokenService) Create(card Card) (*TokenResponse, error) {
-	var errors []string
-	if card.Number == nil {
-		errors = append(errors, "Number is required")
-	}
-	if card.ExpMonth == nil {
-		errors = append(errors, "ExpMonth is required")
-	}
-	if card.ExpYear == nil {
-		errors = append(errors, "ExpYear is required")
-	}
-	if len(errors) != 0 {
-		return nil, fmt.Errorf("payjp.Token.Create() parameter error: %s", strings.Join(errors, ", "))
-	}
-	qb := newRequestBuilder()
-	qb.AddCard(card)
+	return t.CreateWithContext(context.Background(), card)
+}
 
-	request, err := http.NewRequest("POST", t.service.apiBase+"/tokens", qb.Reader())
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	request.Header.Add("Authorization", t.service.apiKey)
+// CreateWithContext はcontext.Contextを指定してトークンを生成します。
+func (t TokenService) CreateWithContext(ctx context.Context, card Card) (*TokenResponse, error) {
+	result, err := t.service.call(ctx, "token.create", "", func(ctx context.Context) (interface{}, error) {
+		var errors []string
+		if card.Number == nil {
+			errors = append(errors, "Number is required")
+		}
+		if card.ExpMonth == nil {
+			errors = append(errors, "ExpMonth is required")
+		}
+		if card.ExpYear == nil {
+			errors = append(errors, "ExpYear is required")
+		}
+		if len(errors) != 0 {
+			return nil, fmt.Errorf("payjp.Token.Create() parameter error: %s", strings.Join(errors, ", "))
+		}
+		if err := card.validate(); err != nil {
+			return nil, err
+		}
+		values, err := encodeForm(newCardParams(card))
+		if err != nil {
+			return nil, err
+		}
 
-	return parseToken(respToBody(t.service.Client.Do(request)))
+		return parseToken(respToBody(t.service.request(ctx, "POST", "/tokens", formBody(values))))
+	})
+	response, _ := result.(*TokenResponse)
+	return response, err
 }
 
 // Retrieve token object. 特定のトークン情報を取得します。
 func (t TokenService) Retrieve(id string) (*TokenResponse, error) {
-	return parseToken(t.service.retrieve("/tokens/" + id))
+	return t.RetrieveWithContext(context.Background(), id)
+}
+
+// RetrieveWithContext はcontext.Contextを指定して特定のトークン情報を取得します。
+func (t TokenService) RetrieveWithContext(ctx context.Context, id string) (*TokenResponse, error) {
+	result, err := t.service.call(ctx, "token.retrieve", id, func(ctx context.Context) (interface{}, error) {
+		return parseToken(t.service.retrieve(ctx, "/tokens/"+id))
+	})
+	response, _ := result.(*TokenResponse)
+	return response, err
+}
+
+// TdsFinish は3Dセキュア認証が終了したトークンの処理を完了させます。
+//
+// payjp.jsなどで3Dセキュア認証を行って作成したトークンは、このメソッドを呼ぶまで支払いや顧客のカード登録に使用できません。
+func (t TokenService) TdsFinish(id string) (*TokenResponse, error) {
+	return t.TdsFinishWithContext(context.Background(), id)
+}
+
+// TdsFinishWithContext はcontext.Contextを指定して3Dセキュア認証が終了したトークンの処理を完了させます。
+func (t TokenService) TdsFinishWithContext(ctx context.Context, id string) (*TokenResponse, error) {
+	result, err := t.service.call(ctx, "token.tds_finish", id, func(ctx context.Context) (interface{}, error) {
+		return parseToken(respToBody(t.service.request(ctx, "POST", "/tokens/"+id+"/tds_finish", nil)))
+	})
+	response, _ := result.(*TokenResponse)
+	return response, err
 }
 
 // TokenResponse はToken.Create(), Token.Retrieve()が返す構造体です。
@@ -1,8 +1,13 @@
 package payjp
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
+
+	"github.com/payjp/payjp-go/v1/cardvalidate"
 )
 
 var tokenResponseJSON = []byte(`
@@ -51,9 +56,9 @@ func TestTokenCreate(t *testing.T) {
 	mock, transport := NewMockClient(200, tokenResponseJSON)
 	service := New("api-key", mock)
 	token, err := service.Token.Create(Card{
-		Number:   "4242424242424242",
-		ExpMonth: 2,
-		ExpYear:  2020,
+		Number:   String("4242424242424242"),
+		ExpMonth: Int(2),
+		ExpYear:  Int(2099),
 	})
 	if transport.URL != "https://api.pay.jp/v1/tokens" {
 		t.Errorf("URL is wrong: %s", transport.URL)
@@ -72,6 +77,74 @@ func TestTokenCreate(t *testing.T) {
 	}
 }
 
+func TestTokenCreateInvalidCard(t *testing.T) {
+	mock, transport := NewMockClient(200, tokenResponseJSON)
+	service := New("api-key", mock)
+	_, err := service.Token.Create(Card{
+		Number:   String("4242424242424241"),
+		ExpMonth: Int(2),
+		ExpYear:  Int(2099),
+	})
+	if transport.URL != "" {
+		t.Errorf("request should not be sent, but %s", transport.URL)
+	}
+	var payjpErr *Error
+	if !errors.As(err, &payjpErr) || payjpErr.Code != "invalid_number" || payjpErr.Param != "card[number]" {
+		t.Fatalf("err should be invalid_number, but %v", err)
+	}
+	if !errors.Is(err, ErrCardError) {
+		t.Error("err should be ErrCardError")
+	}
+}
+
+func TestTokenCreateInvalidCardFields(t *testing.T) {
+	mock, _ := NewMockClient(200, tokenResponseJSON)
+	service := New("api-key", mock)
+	_, err := service.Token.Create(Card{
+		Number:   String("4242424242424241"),
+		ExpMonth: Int(13),
+		ExpYear:  Int(2099),
+		CVC:      String("12"),
+	})
+	var payjpErr *Error
+	if !errors.As(err, &payjpErr) || payjpErr.Code != "invalid_number" {
+		t.Fatalf("err should be invalid_number, but %v", err)
+	}
+	var errs cardvalidate.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("err should contain cardvalidate.Errors, but %v", err)
+	}
+	var codes []string
+	for _, e := range errs {
+		codes = append(codes, e.Code)
+	}
+	if strings.Join(codes, ",") != "invalid_number,invalid_expiry_month,invalid_cvc" {
+		t.Errorf("all validation errors should be kept, but %v", codes)
+	}
+}
+
+func TestTokenCardUpdateDelete(t *testing.T) {
+	mock, transport := NewMockClient(200, tokenResponseJSON)
+	service := New("api-key", mock)
+	token, err := service.Token.Retrieve("tok_5ca06b51685e001723a2c3b4aeb4")
+	if err != nil {
+		t.Fatalf("err should be nil, but %v", err)
+	}
+	transport.URL = ""
+	if err := token.Card.Update(Card{Name: String("pay")}); err == nil {
+		t.Error("Update() of token's card should return error")
+	}
+	if err := token.Card.Delete(); err == nil {
+		t.Error("Delete() of token's card should return error")
+	}
+	if err := (&CardResponse{ID: "car_f7d9fa98594dc7c2e42bfcd641ff"}).Delete(); err == nil {
+		t.Error("Delete() of card without service should return error")
+	}
+	if transport.URL != "" {
+		t.Errorf("request should not be sent, but %s", transport.URL)
+	}
+}
+
 func TestTokenRetrieve(t *testing.T) {
 	mock, transport := NewMockClient(200, tokenResponseJSON)
 	service := New("api-key", mock)
@@ -91,3 +164,23 @@ func TestTokenRetrieve(t *testing.T) {
 		t.Errorf("parse error: plan.Amount should be tok_5ca06b51685e001723a2c3b4aeb4, but %s.", token.ID)
 	}
 }
+
+func TestTokenTdsFinish(t *testing.T) {
+	mock, transport := NewMockClient(200, bytes.Replace(tokenResponseJSON, []byte(`"object": "card"`), []byte(`"object": "card",
+    "three_d_secure_status": "attempted"`), 1))
+	service := New("api-key", mock)
+	token, err := service.Token.TdsFinish("tok_5ca06b51685e001723a2c3b4aeb4")
+	if transport.URL != "https://api.pay.jp/v1/tokens/tok_5ca06b51685e001723a2c3b4aeb4/tds_finish" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+	if transport.Method != "POST" {
+		t.Errorf("Method should be POST, but %s", transport.Method)
+	}
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+		return
+	}
+	if token.Card.ThreeDSecureStatus != ThreeDSecureAttempted {
+		t.Errorf("token.Card.ThreeDSecureStatus should be ThreeDSecureAttempted, but %v", token.Card.ThreeDSecureStatus)
+	}
+}
@@ -0,0 +1,70 @@
+package payjp
+
+import (
+	"context"
+	"errors"
+)
+
+// Tracer はAPI呼び出しごとにスパンを開始するインタフェースです。Config.Tracerに指定します。
+//
+// OpenTelemetryを使用する場合は、otelpayjpパッケージのNewTracerでtrace.Tracerから作成できます。
+type Tracer interface {
+	// Start はctxを親とするスパンを開始し、スパンを含むcontext.Contextを返します。
+	// 返されたcontext.ContextはPAY.JPへのリクエストに使用されます。
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+// Span はTracerが開始したスパンです。
+type Span interface {
+	// SetAttribute はスパンに属性を設定します。valueはstring、int、boolのいずれかです。
+	SetAttribute(key string, value interface{})
+	// End はスパンを終了します。API呼び出しが失敗した場合、errにそのエラーが渡されます。
+	End(err error)
+}
+
+// スパンに設定する属性のキーです。
+const (
+	AttributeOperation  = "payjp.operation"   // 論理的な操作名(e.g. "charge.create")
+	AttributeResourceID = "payjp.resource_id" // 操作の対象となるリソースのID
+	AttributeRetryCount = "payjp.retry_count" // 再試行した回数
+	AttributeRequestID  = "payjp.request_id"  // PAY.JPのリクエストID
+	AttributeErrorType  = "payjp.error.type"  // PAY.JPのエラーのtype
+	AttributeErrorCode  = "payjp.error.code"  // PAY.JPのエラーのcode
+	AttributeHTTPMethod = "http.method"       // HTTPメソッド
+	AttributeHTTPStatus = "http.status_code"  // HTTPステータス
+)
+
+// tracingInterceptor はAPI呼び出しごとに"payjp.<操作名>"のスパンを作成するInterceptorを返します。
+func tracingInterceptor(tracer Tracer) Interceptor {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) (interface{}, error) {
+			ctx, span := tracer.Start(ctx, "payjp."+call.Operation)
+			span.SetAttribute(AttributeOperation, call.Operation)
+			if call.ResourceID != "" {
+				span.SetAttribute(AttributeResourceID, call.ResourceID)
+			}
+			result, err := next(ctx, call)
+			if call.Attempts > 0 {
+				span.SetAttribute(AttributeHTTPMethod, call.Method)
+				span.SetAttribute(AttributeRetryCount, call.Attempts-1)
+			}
+			if call.StatusCode != 0 {
+				span.SetAttribute(AttributeHTTPStatus, call.StatusCode)
+			}
+			if requestID := call.Header.Get(requestIDHeader); requestID != "" {
+				span.SetAttribute(AttributeRequestID, requestID)
+			}
+			var payjpError *Error
+			if errors.As(err, &payjpError) {
+				if payjpError.Type != "" {
+					span.SetAttribute(AttributeErrorType, payjpError.Type)
+				}
+				if payjpError.Code != "" {
+					span.SetAttribute(AttributeErrorCode, payjpError.Code)
+				}
+			}
+			span.End(err)
+			return result, err
+		}
+	}
+}
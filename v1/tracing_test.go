@@ -0,0 +1,84 @@
+package payjp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testSpanKey struct{}
+
+type testSpan struct {
+	name       string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *testSpan) SetAttribute(key string, value interface{}) {
+	s.attributes[key] = value
+}
+
+func (s *testSpan) End(err error) {
+	s.err = err
+	s.ended = true
+}
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, spanName string) (context.Context, Span) {
+	span := &testSpan{name: spanName, attributes: map[string]interface{}{}}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, testSpanKey{}, span), span
+}
+
+func TestTracer(t *testing.T) {
+	var requestSpan interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_1234")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(chargeErrorResponseJSON)
+	}))
+	defer server.Close()
+	tracer := &testTracer{}
+	service := New("api-key", nil, Config{APIBase: server.URL, Tracer: tracer})
+	service.Use(func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) (interface{}, error) {
+			requestSpan = ctx.Value(testSpanKey{})
+			return next(ctx, call)
+		}
+	})
+
+	_, err := service.Charge.Capture("ch_fa990a4c10672a93053a774730b0a")
+	if err == nil {
+		t.Fatal("err should not be nil")
+	}
+	if len(tracer.spans) != 1 {
+		t.Fatalf("tracer should start one span, but %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != "payjp.charge.capture" || !span.ended || span.err != err {
+		t.Errorf("span is wrong: %s %v %v", span.name, span.ended, span.err)
+	}
+	if requestSpan != span {
+		t.Error("span should be linked to the context of the API call")
+	}
+	expected := map[string]interface{}{
+		AttributeOperation:  "charge.capture",
+		AttributeResourceID: "ch_fa990a4c10672a93053a774730b0a",
+		AttributeHTTPMethod: "POST",
+		AttributeHTTPStatus: 400,
+		AttributeRetryCount: 0,
+		AttributeRequestID:  "req_1234",
+		AttributeErrorType:  "card_error",
+		AttributeErrorCode:  "invalid_number",
+	}
+	for key, value := range expected {
+		if span.attributes[key] != value {
+			t.Errorf("attribute %s should be %v, but %v", key, value, span.attributes[key])
+		}
+	}
+}
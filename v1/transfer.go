@@ -1,6 +1,7 @@
 package payjp
 
 import (
+	"context"
 	"encoding/json"
 	"net/url"
 	"time"
@@ -25,6 +26,24 @@ const (
 	TransferStop
 )
 
+func parseTransferStatus(status string) TransferStatus {
+	switch status {
+	case "pending":
+		return TransferPending
+	case "paid":
+		return TransferPaid
+	case "failed":
+		return TransferFailed
+	case "recombination":
+		return TransferRecombination
+	case "carried_over":
+		return TransferCarriedOver
+	case "stop":
+		return TransferStop
+	}
+	return noTransferStatus
+}
+
 func (t TransferStatus) status() interface{} {
 	switch t {
 	case TransferPending:
@@ -58,17 +77,26 @@ func newTransferService(service *Service) *TransferService {
 
 // Retrieve transfer object. 入金情報を取得します。
 func (t TransferService) Retrieve(transferID string) (*TransferResponse, error) {
-	body, err := t.service.retrieve("/transfers/" + transferID)
-	if err != nil {
-		return nil, err
-	}
-	result := &TransferResponse{}
-	err = json.Unmarshal(body, result)
-	if err != nil {
-		return nil, err
-	}
-	result.service = t.service
-	return result, nil
+	return t.RetrieveWithContext(context.Background(), transferID)
+}
+
+// RetrieveWithContext はcontext.Contextを指定して入金情報を取得します。
+func (t TransferService) RetrieveWithContext(ctx context.Context, transferID string) (*TransferResponse, error) {
+	result, err := t.service.call(ctx, "transfer.retrieve", transferID, func(ctx context.Context) (interface{}, error) {
+		body, err := t.service.retrieve(ctx, "/transfers/"+transferID)
+		if err != nil {
+			return nil, err
+		}
+		result := &TransferResponse{}
+		err = json.Unmarshal(body, result)
+		if err != nil {
+			return nil, err
+		}
+		result.service = t.service
+		return result, nil
+	})
+	response, _ := result.(*TransferResponse)
+	return response, err
 }
 
 // List は入金リストを取得します。リストは、直近で生成された順番に取得されます。
@@ -81,14 +109,14 @@ func (t TransferService) List() *TransferListCaller {
 
 // TransferListCaller は支払いのリスト取得に使用する構造体です。
 type TransferListCaller struct {
-	service           *Service
-	limit             int
-	offset            int
-	since             int
-	until             int
-	sinceSheduledDate int
-	untilSheduledDate int
-	status            TransferStatus
+	service            *Service
+	limit              int
+	offset             int
+	since              int
+	until              int
+	sinceScheduledDate int
+	untilScheduledDate int
+	status             TransferStatus
 }
 
 // Limit はリストの要素数の最大値を設定します(1-100)
@@ -103,18 +131,32 @@ func (c *TransferListCaller) Offset(offset int) *TransferListCaller {
 	return c
 }
 
-// SinceSheduledDate は入金予定日がここに指定したタイムスタンプ以降のデータのみ取得します
-func (c *TransferListCaller) SinceSheduledDate(sinceSheduledDate time.Time) *TransferListCaller {
-	c.sinceSheduledDate = int(sinceSheduledDate.Unix())
+// SinceScheduledDate は入金予定日がここに指定したタイムスタンプ以降のデータのみ取得します
+func (c *TransferListCaller) SinceScheduledDate(sinceScheduledDate time.Time) *TransferListCaller {
+	c.sinceScheduledDate = int(sinceScheduledDate.Unix())
 	return c
 }
 
-// UntilSheduledDate は入金予定日がここに指定したタイムスタンプ以前のデータのみ取得します
-func (c *TransferListCaller) UntilSheduledDate(untilSheduledDate time.Time) *TransferListCaller {
-	c.untilSheduledDate = int(untilSheduledDate.Unix())
+// UntilScheduledDate は入金予定日がここに指定したタイムスタンプ以前のデータのみ取得します
+func (c *TransferListCaller) UntilScheduledDate(untilScheduledDate time.Time) *TransferListCaller {
+	c.untilScheduledDate = int(untilScheduledDate.Unix())
 	return c
 }
 
+// SinceSheduledDate はSinceScheduledDateの旧名です。
+//
+// Deprecated: SinceScheduledDateを使用してください。
+func (c *TransferListCaller) SinceSheduledDate(sinceScheduledDate time.Time) *TransferListCaller {
+	return c.SinceScheduledDate(sinceScheduledDate)
+}
+
+// UntilSheduledDate はUntilScheduledDateの旧名です。
+//
+// Deprecated: UntilScheduledDateを使用してください。
+func (c *TransferListCaller) UntilSheduledDate(untilScheduledDate time.Time) *TransferListCaller {
+	return c.UntilScheduledDate(untilScheduledDate)
+}
+
 // Since はここに指定したタイムスタンプ以降に作成されたデータを取得します
 func (c *TransferListCaller) Since(since time.Time) *TransferListCaller {
 	c.since = int(since.Unix())
@@ -135,48 +177,98 @@ func (c *TransferListCaller) Status(status TransferStatus) *TransferListCaller {
 
 // Do は指定されたクエリーを元に入金のリストを配列で取得します。
 func (c *TransferListCaller) Do() ([]*TransferResponse, bool, error) {
-	body, err := c.service.queryTransferList("/transfers", c.limit, c.offset, c.since, c.until, c.sinceSheduledDate, c.untilSheduledDate, func(values *url.Values) bool {
-		if c.status != noTransferStatus {
-			values.Add("status", c.status.status().(string))
-			return true
+	return c.DoContext(context.Background())
+}
+
+// DoContext はcontext.Contextを指定して入金のリストを配列で取得します。
+func (c *TransferListCaller) DoContext(ctx context.Context) ([]*TransferResponse, bool, error) {
+	var hasMore bool
+	result, err := c.service.call(ctx, "transfer.list", "", func(ctx context.Context) (interface{}, error) {
+		body, err := c.service.queryTransferList(ctx, "/transfers", c.limit, c.offset, c.since, c.until, c.sinceScheduledDate, c.untilScheduledDate, func(values *url.Values) bool {
+			if c.status != noTransferStatus {
+				values.Add("status", c.status.status().(string))
+				return true
+			}
+			return false
+		})
+		if err != nil {
+			return nil, err
+		}
+		raw := &listResponseParser{}
+		err = json.Unmarshal(body, raw)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]*TransferResponse, len(raw.Data))
+		for i, rawCharge := range raw.Data {
+			charge := &TransferResponse{}
+			json.Unmarshal(rawCharge, charge)
+			charge.service = c.service
+			result[i] = charge
 		}
-		return false
+		hasMore = raw.HasMore
+		return result, nil
 	})
-	if err != nil {
-		return nil, false, err
-	}
-	raw := &listResponseParser{}
-	err = json.Unmarshal(body, raw)
-	if err != nil {
-		return nil, false, err
-	}
-	result := make([]*TransferResponse, len(raw.Data))
-	for i, rawCharge := range raw.Data {
-		charge := &TransferResponse{}
-		json.Unmarshal(rawCharge, charge)
-		charge.service = c.service
-		result[i] = charge
-	}
-	return result, raw.HasMore, nil
+	list, _ := result.([]*TransferResponse)
+	return list, hasMore, err
+}
+
+// Iter は入金のリストを順に取得するイテレータを返します。次のページは自動的に取得されます。
+func (c *TransferListCaller) Iter() *TransferIter {
+	return c.IterContext(context.Background())
+}
+
+// IterContext はcontext.Contextを指定してイテレータを返します。ctxがキャンセルされると取得を中断します。
+func (c *TransferListCaller) IterContext(ctx context.Context) *TransferIter {
+	caller := *c
+	return &TransferIter{newListIter(ctx, c.offset, c.limit, func(ctx context.Context, offset, limit int) ([]interface{}, bool, error) {
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	})}
+}
+
+// TransferIter は入金のリストを順に取得するイテレータです。
+type TransferIter struct {
+	listIter
+}
+
+// Max は取得する要素数の上限を設定します。0の場合は上限なしです。
+func (it *TransferIter) Max(n int) *TransferIter {
+	it.max = n
+	return it
+}
+
+// Value は現在の要素を返します。
+func (it *TransferIter) Value() *TransferResponse {
+	value, _ := it.current.(*TransferResponse)
+	return value
 }
 
 // ChargeList は支払いは入金内訳リストを取得します。リストは、直近で生成された順番に取得されます。
 func (t TransferService) ChargeList(transferID string) *TransferChargeListCaller {
 	return &TransferChargeListCaller{
-		service:    t.service,
-		transferID: transferID,
+		service:      t.service,
+		resourcePath: "/transfers/",
+		transferID:   transferID,
 	}
 }
 
 // TransferChargeListCaller は入金内訳のリスト取得に使用する構造体です。
 type TransferChargeListCaller struct {
-	service    *Service
-	transferID string
-	limit      int
-	offset     int
-	since      int
-	until      int
-	customerID string
+	service      *Service
+	resourcePath string
+	transferID   string
+	limit        int
+	offset       int
+	since        int
+	until        int
+	customerID   string
 }
 
 // Limit はリストの要素数の最大値を設定します(1-100)
@@ -211,30 +303,78 @@ func (c *TransferChargeListCaller) CustomerID(ID string) *TransferChargeListCall
 
 // Do は指定されたクエリーを元に入金内訳のリストを配列で取得します。
 func (c *TransferChargeListCaller) Do() ([]*ChargeResponse, bool, error) {
-	path := "/transfers/" + c.transferID + "/charges"
-	body, err := c.service.queryList(path, c.limit, c.offset, c.since, c.until, func(values *url.Values) bool {
-		if c.customerID != "" {
-			values.Add("customer", c.customerID)
-			return true
+	return c.DoContext(context.Background())
+}
+
+// DoContext はcontext.Contextを指定して入金内訳のリストを配列で取得します。
+func (c *TransferChargeListCaller) DoContext(ctx context.Context) ([]*ChargeResponse, bool, error) {
+	var hasMore bool
+	result, err := c.service.call(ctx, "transfer.list_charges", c.transferID, func(ctx context.Context) (interface{}, error) {
+		path := c.resourcePath + c.transferID + "/charges"
+		body, err := c.service.queryList(ctx, path, c.limit, c.offset, c.since, c.until, func(values *url.Values) bool {
+			if c.customerID != "" {
+				values.Add("customer", c.customerID)
+				return true
+			}
+			return false
+		})
+		if err != nil {
+			return nil, err
+		}
+		raw := &listResponseParser{}
+		err = json.Unmarshal(body, raw)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]*ChargeResponse, len(raw.Data))
+		for i, rawCharge := range raw.Data {
+			transfer := &ChargeResponse{}
+			json.Unmarshal(rawCharge, transfer)
+			transfer.service = c.service
+			result[i] = transfer
 		}
-		return false
+		hasMore = raw.HasMore
+		return result, nil
 	})
-	if err != nil {
-		return nil, false, err
-	}
-	raw := &listResponseParser{}
-	err = json.Unmarshal(body, raw)
-	if err != nil {
-		return nil, false, err
-	}
-	result := make([]*ChargeResponse, len(raw.Data))
-	for i, rawCharge := range raw.Data {
-		transfer := &ChargeResponse{}
-		json.Unmarshal(rawCharge, transfer)
-		transfer.service = c.service
-		result[i] = transfer
-	}
-	return result, raw.HasMore, nil
+	list, _ := result.([]*ChargeResponse)
+	return list, hasMore, err
+}
+
+// Iter は入金内訳のリストを順に取得するイテレータを返します。次のページは自動的に取得されます。
+func (c *TransferChargeListCaller) Iter() *TransferChargeIter {
+	return c.IterContext(context.Background())
+}
+
+// IterContext はcontext.Contextを指定してイテレータを返します。ctxがキャンセルされると取得を中断します。
+func (c *TransferChargeListCaller) IterContext(ctx context.Context) *TransferChargeIter {
+	caller := *c
+	return &TransferChargeIter{newListIter(ctx, c.offset, c.limit, func(ctx context.Context, offset, limit int) ([]interface{}, bool, error) {
+		caller.offset = offset
+		caller.limit = limit
+		list, hasMore, err := caller.DoContext(ctx)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, hasMore, err
+	})}
+}
+
+// TransferChargeIter は入金内訳のリストを順に取得するイテレータです。
+type TransferChargeIter struct {
+	listIter
+}
+
+// Max は取得する要素数の上限を設定します。0の場合は上限なしです。
+func (it *TransferChargeIter) Max(n int) *TransferChargeIter {
+	it.max = n
+	return it
+}
+
+// Value は現在の要素を返します。
+func (it *TransferChargeIter) Value() *ChargeResponse {
+	value, _ := it.current.(*ChargeResponse)
+	return value
 }
 
 // TransferResponse はTransferService.Get、TransferService.Listによって返される、
@@ -289,6 +429,8 @@ type transferResponseParser struct {
 		RefundCount   int `json:"refund_count"`
 		DisputeAmount int `json:"dispute_amount"`
 		DisputeCount  int `json:"dispute_count"`
+
+		TotalPlatformFee int `json:"total_platform_fee"` // テナントへの入金のみ
 	} `json:"summary"`
 	TermEndEpoch   int    `json:"term_end"`
 	TermStartEpoch int    `json:"term_start"`
@@ -311,20 +453,7 @@ func (t *TransferResponse) UnmarshalJSON(b []byte) error {
 		t.ID = raw.ID
 		t.LiveMode = raw.LiveMode
 		t.ScheduledDate = raw.ScheduledDate
-		switch raw.Status {
-		case "pending":
-			t.Status = TransferPending
-		case "paid":
-			t.Status = TransferPaid
-		case "failed":
-			t.Status = TransferFailed
-		case "recombination":
-			t.Status = TransferRecombination
-		case "carried_over":
-			t.Status = TransferCarriedOver
-		case "stop":
-			t.Status = TransferStop
-		}
+		t.Status = parseTransferStatus(raw.Status)
 		t.Summary.ChargeCount = raw.Summary.ChargeCount
 		t.Summary.ChargeFee = raw.Summary.ChargeFee
 		t.Summary.ChargeGross = raw.Summary.ChargeGross
@@ -189,6 +189,20 @@ func TestTransferList(t *testing.T) {
 	}
 }
 
+func TestTransferListScheduledDate(t *testing.T) {
+	mock, transport := NewMockClient(200, transferListResponseJSON)
+	service := New("api-key", mock)
+	_, _, err := service.Transfer.List().
+		SinceScheduledDate(time.Unix(1455328095, 0)).
+		UntilScheduledDate(time.Unix(1455500895, 0)).Do()
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	}
+	if transport.URL != "https://api.pay.jp/v1/transfers?since_scheduled_date=1455328095&until_scheduled_date=1455500895" {
+		t.Errorf("URL is wrong: %s", transport.URL)
+	}
+}
+
 func TestTransferChargeList(t *testing.T) {
 	mock, transport := NewMockClient(200, transferChargeListResponseJSON)
 	service := New("api-key", mock)
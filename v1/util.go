@@ -3,86 +3,33 @@ package payjp
 import (
 	"bytes"
 	"encoding/json"
-	"io"
 	"io/ioutil"
 	"net/http"
-	"net/url"
-	"strconv"
+	"time"
 )
 
-type requestBuilder struct {
-	buffer    *bytes.Buffer
-	delimiter byte
-	hasValue  bool
-}
-
-func newRequestBuilder() *requestBuilder {
-	return &requestBuilder{
-		buffer:    &bytes.Buffer{},
-		delimiter: '&',
-	}
-}
-
-func (qb *requestBuilder) Add(key string, value interface{}) {
-	if value == nil {
-		return
-	}
-	var valueString string
-	s, ok := value.(string)
-	if ok {
-		valueString = url.QueryEscape(s)
-	} else {
-		b, ok := value.(bool)
-		if ok {
-			if b {
-				valueString = "true"
-			} else {
-				valueString = "false"
-			}
-		} else {
-			valueString = strconv.Itoa(value.(int))
-		}
-	}
-	if qb.hasValue {
-		qb.buffer.WriteByte(qb.delimiter)
-	}
-	qb.hasValue = true
-	qb.buffer.WriteString(key)
-	qb.buffer.WriteByte('=')
-	qb.buffer.WriteString(valueString)
-}
-
-func (qb *requestBuilder) AddCard(card Card) {
-	qb.Add("card[number]", card.Number)
-	qb.Add("card[exp_month]", card.ExpMonth)
-	qb.Add("card[exp_year]", card.ExpYear)
-	qb.Add("card[cvc]", card.CVC)
-	qb.Add("card[address_state]", card.AddressState)
-	qb.Add("card[address_city]", card.AddressCity)
-	qb.Add("card[address_line1]", card.AddressLine1)
-	qb.Add("card[address_line2]", card.AddressLine2)
-	qb.Add("card[address_zip]", card.AddressZip)
-	qb.Add("card[country]", card.Country)
-	qb.Add("card[name]", card.Name)
-	qb.AddMetadata(card.Metadata)
-}
-
-func (qb *requestBuilder) AddMetadata(metadata map[string]string) {
-	for key, value := range metadata {
-		qb.Add("metadata["+key+"]", value)
-	}
-}
-
-func (qb *requestBuilder) Reader() io.Reader {
-	return qb.buffer
-}
-
+// respToBody はレスポンスボディを読み込みます。
+//
+// HTTPステータスが2xx以外の場合や、ボディが空の場合はErrorを返します。
 func respToBody(resp *http.Response, err error) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
-	return ioutil.ReadAll(resp.Body)
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &NetworkError{Err: err}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if payjpError := parseErrorResponse(resp, body); payjpError != nil {
+			return nil, payjpError
+		}
+		return nil, newStatusError(resp, body, http.StatusText(resp.StatusCode))
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, newStatusError(resp, body, "empty response body")
+	}
+	return body, nil
 }
 
 type listResponseParser struct {
@@ -110,3 +57,11 @@ func (p *listResponseParser) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+// epochToTime はnullになりうるタイムスタンプを変換します。未設定の場合はゼロ値を返します。
+func epochToTime(epoch int) time.Time {
+	if epoch == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(epoch), 0)
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 )
 
 func NewMockClient(status int, response []byte) (*http.Client, *MockTransport) {
@@ -52,3 +54,14 @@ func (t *MockTransport) AddResponse(status int, body []byte) {
 		response: body,
 	})
 }
+
+// newFormRecordingServer はresponseを返し、最後に受け取ったリクエストのフォームを記録するサーバーです。
+func newFormRecordingServer(response []byte) (*httptest.Server, *url.Values) {
+	form := &url.Values{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		*form = r.Form
+		w.Write(response)
+	}))
+	return server, form
+}
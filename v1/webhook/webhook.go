@@ -0,0 +1,132 @@
+// Package webhook はPAY.JPから送信されるWebhookを受信するhttp.Handlerを提供します。
+//
+// PAY.JPの管理画面で確認できるWebhookトークンと、イベントを処理するコールバックを指定して使用します:
+//
+//     handler := webhook.NewHandler("whook_xxxxx", func(ctx context.Context, event *payjp.EventResponse) error {
+//         if event.Type == "charge.succeeded" {
+//             charge, err := event.ChargeData()
+//             ...
+//         }
+//         return nil
+//     })
+//     http.Handle("/webhook", handler)
+//
+// コールバックでChargeData()などから取得したオブジェクトのCapture()などを呼び出す場合は、
+// Handler.Serviceにpayjp.Serviceを設定します:
+//
+//     handler.Service = payjp.New("sk_live_xxxxx", nil)
+//
+// イベントの種類ごとに処理を分ける場合は、payjp.EventRouterのDispatchをコールバックに指定します:
+//
+//     handler := webhook.NewHandler("whook_xxxxx", router.Dispatch)
+//
+// - PAY.JP Webhook: https://pay.jp/docs/webhook
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/payjp/payjp-go/v1"
+)
+
+// TokenHeader はWebhookトークンが設定されるリクエストヘッダーの名前です。
+const TokenHeader = "X-Payjp-Webhook-Token"
+
+// DefaultMaxBodyBytes はHandler.MaxBodyBytesを省略した時に受け付けるリクエストボディの最大サイズです。
+const DefaultMaxBodyBytes = 1 << 20
+
+// Callback は受信したイベントを処理する関数です。
+// エラーを返すと、PAY.JPにはステータス500が返され、Webhookが再送されます。
+type Callback func(ctx context.Context, event *payjp.EventResponse) error
+
+// Handler はWebhookを受信するhttp.Handlerです。NewHandler()を使ってインスタンスを生成します。
+//
+// レスポンスのステータスは以下の通りです:
+//
+//     200: すべてのコールバックが成功した
+//     400: リクエストボディがイベントとして解釈できない
+//     401: Webhookトークンが一致しない
+//     405: POST以外のメソッド
+//     413: リクエストボディがMaxBodyBytesを超えている
+//     500: コールバックがエラーを返した
+type Handler struct {
+	MaxBodyBytes int64                            // 受け付けるリクエストボディの最大サイズ(省略時はDefaultMaxBodyBytes)
+	ErrorLog     func(r *http.Request, err error) // コールバックのエラーの通知先(省略可)
+	Service      *payjp.Service                   // イベントから取得したオブジェクトのAPI呼び出しに使うサービス(省略可)
+
+	token     []byte
+	callbacks []Callback
+}
+
+// NewHandler はWebhookトークンとコールバックを指定してHandlerを生成します。
+// コールバックは指定された順に呼ばれ、エラーを返した時点で以降のコールバックは呼ばれません。
+func NewHandler(token string, callbacks ...Callback) *Handler {
+	return &Handler{
+		token:     []byte(token),
+		callbacks: callbacks,
+	}
+}
+
+// ServeHTTP はhttp.Handlerインタフェースの実装です。
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.validToken(r.Header.Get(TokenHeader)) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	event, status := h.parseEvent(w, r)
+	if event == nil {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	for _, callback := range h.callbacks {
+		if err := callback(r.Context(), event); err != nil {
+			if h.ErrorLog != nil {
+				h.ErrorLog(r, err)
+			}
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// validToken はWebhookトークンを定数時間で比較します。
+func (h *Handler) validToken(token string) bool {
+	if len(h.token) == 0 {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), h.token) == 1
+}
+
+func (h *Handler) parseEvent(w http.ResponseWriter, r *http.Request) (*payjp.EventResponse, int) {
+	maxBodyBytes := h.MaxBodyBytes
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = DefaultMaxBodyBytes
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		if int64(len(body)) >= maxBodyBytes {
+			return nil, http.StatusRequestEntityTooLarge
+		}
+		return nil, http.StatusBadRequest
+	}
+	event := &payjp.EventResponse{}
+	if h.Service != nil {
+		event, err = h.Service.Event.Parse(body)
+	} else {
+		err = json.Unmarshal(body, event)
+	}
+	if err != nil || event.ID == "" {
+		return nil, http.StatusBadRequest
+	}
+	return event, http.StatusOK
+}
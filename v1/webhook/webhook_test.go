@@ -0,0 +1,286 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/payjp/payjp-go/v1"
+)
+
+var chargeEventJSON = []byte(`
+{
+  "created": 1442298026,
+  "data": {
+    "amount": 5000,
+    "amount_refunded": 0,
+    "captured": true,
+    "captured_at": 1442212986,
+    "card": {
+      "brand": "Visa",
+      "created": 1442212986,
+      "exp_month": 1,
+      "exp_year": 2016,
+      "id": "car_f0984a6f68a730b7e1814ceabfe1",
+      "last4": "4242",
+      "object": "card"
+    },
+    "created": 1442212986,
+    "currency": "jpy",
+    "id": "ch_bcb7776459913c743c20e9f9351d4",
+    "livemode": false,
+    "object": "charge",
+    "paid": true,
+    "refunded": false
+  },
+  "id": "evnt_8064917698511d76ccfa6f4fee0",
+  "livemode": false,
+  "object": "event",
+  "pending_webhooks": 1,
+  "type": "charge.succeeded"
+}
+`)
+
+var customerEventJSON = []byte(`
+{
+  "created": 1442298026,
+  "data": {
+    "cards": {
+      "count": 1,
+      "data": [
+        {
+          "brand": "Visa",
+          "created": 1433127983,
+          "exp_month": 2,
+          "exp_year": 2020,
+          "id": "car_f7d9fa98594dc7c2e42bfcd641ff",
+          "last4": "4242",
+          "object": "card"
+        }
+      ],
+      "has_more": false,
+      "object": "list",
+      "url": "/v1/customers/cus_121673955bd7aa144de5a8f6c262/cards"
+    },
+    "created": 1433127983,
+    "id": "cus_121673955bd7aa144de5a8f6c262",
+    "livemode": false,
+    "object": "customer"
+  },
+  "id": "evnt_54db4d63c7886256acdbc784ccf",
+  "livemode": false,
+  "object": "event",
+  "pending_webhooks": 1,
+  "type": "customer.updated"
+}
+`)
+
+var cardEventJSON = []byte(`
+{
+  "created": 1442298026,
+  "data": {
+    "brand": "Visa",
+    "created": 1433127983,
+    "customer": "cus_121673955bd7aa144de5a8f6c262",
+    "exp_month": 2,
+    "exp_year": 2020,
+    "id": "car_f7d9fa98594dc7c2e42bfcd641ff",
+    "last4": "4242",
+    "object": "card"
+  },
+  "id": "evnt_2f7436fe0017098bc8d22221d1e",
+  "livemode": false,
+  "object": "event",
+  "pending_webhooks": 1,
+  "type": "customer.card.updated"
+}
+`)
+
+func newRequest(method, token string, body []byte) *http.Request {
+	r := httptest.NewRequest(method, "/webhook", bytes.NewReader(body))
+	if token != "" {
+		r.Header.Set(TokenHeader, token)
+	}
+	return r
+}
+
+func TestHandler(t *testing.T) {
+	var received *payjp.EventResponse
+	handler := NewHandler("whook_token", func(ctx context.Context, event *payjp.EventResponse) error {
+		received = event
+		return nil
+	})
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("POST", "whook_token", chargeEventJSON))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status should be 200, but %d", w.Code)
+	}
+	if received == nil {
+		t.Fatal("callback should be called")
+	}
+	if received.Type != "charge.succeeded" || received.ResultType != payjp.ChargeEvent {
+		t.Errorf("event type is wrong: %s", received.Type)
+	}
+	charge, err := received.ChargeData()
+	if err != nil {
+		t.Errorf("err should be nil, but %v", err)
+	} else if charge.Amount != 5000 {
+		t.Errorf("charge.Amount should be 5000, but %d", charge.Amount)
+	}
+}
+
+func TestHandlerService(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.Method + " " + r.URL.Path
+		w.Write([]byte(`{"object": "charge", "id": "ch_bcb7776459913c743c20e9f9351d4", "amount": 5000, "captured": true}`))
+	}))
+	defer server.Close()
+
+	handler := NewHandler("whook_token", func(ctx context.Context, event *payjp.EventResponse) error {
+		charge, err := event.ChargeData()
+		if err != nil {
+			return err
+		}
+		return charge.CaptureWithContext(ctx)
+	})
+	handler.Service = payjp.New("sk_test_xxxxx", nil, payjp.Config{APIBase: server.URL})
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("POST", "whook_token", chargeEventJSON))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status should be 200, but %d", w.Code)
+	}
+	if path != "POST /charges/ch_bcb7776459913c743c20e9f9351d4/capture" {
+		t.Errorf("charge should be captured through Service, but %s", path)
+	}
+}
+
+func TestHandlerServiceCards(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		if r.Method == "DELETE" {
+			w.Write([]byte(`{"deleted": true, "id": "car_f7d9fa98594dc7c2e42bfcd641ff", "livemode": false}`))
+			return
+		}
+		w.Write([]byte(`{"object": "card", "id": "car_f7d9fa98594dc7c2e42bfcd641ff", "name": "PAY TARO"}`))
+	}))
+	defer server.Close()
+
+	router := payjp.NewEventRouter()
+	router.OnCustomer(func(ctx context.Context, customer *payjp.CustomerResponse) error {
+		return customer.Cards[0].DeleteWithContext(ctx)
+	})
+	router.OnCard(func(ctx context.Context, card *payjp.CardResponse) error {
+		return card.UpdateWithContext(ctx, payjp.Card{Name: payjp.String("PAY TARO")})
+	})
+	handler := NewHandler("whook_token", router.Dispatch)
+	handler.Service = payjp.New("sk_test_xxxxx", nil, payjp.Config{APIBase: server.URL})
+
+	for _, body := range [][]byte{customerEventJSON, cardEventJSON} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest("POST", "whook_token", body))
+		if w.Code != http.StatusOK {
+			t.Errorf("status should be 200, but %d", w.Code)
+		}
+	}
+	expected := []string{
+		"DELETE /customers/cus_121673955bd7aa144de5a8f6c262/cards/car_f7d9fa98594dc7c2e42bfcd641ff",
+		"POST /customers/cus_121673955bd7aa144de5a8f6c262/cards/car_f7d9fa98594dc7c2e42bfcd641ff",
+	}
+	if strings.Join(paths, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("cards should be updated through Service, but %v", paths)
+	}
+}
+
+func TestHandlerInvalidToken(t *testing.T) {
+	called := false
+	handler := NewHandler("whook_token", func(ctx context.Context, event *payjp.EventResponse) error {
+		called = true
+		return nil
+	})
+	for _, token := range []string{"", "whook_wrong", "whook_token_"} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest("POST", token, chargeEventJSON))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status should be 401 for token %q, but %d", token, w.Code)
+		}
+	}
+	if called {
+		t.Error("callback should not be called")
+	}
+}
+
+func TestHandlerEmptyToken(t *testing.T) {
+	handler := NewHandler("")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("POST", "", chargeEventJSON))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status should be 401, but %d", w.Code)
+	}
+}
+
+func TestHandlerMethodNotAllowed(t *testing.T) {
+	handler := NewHandler("whook_token")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("GET", "whook_token", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status should be 405, but %d", w.Code)
+	}
+}
+
+func TestHandlerBadRequest(t *testing.T) {
+	handler := NewHandler("whook_token")
+	for _, body := range []string{"", "not json", `{"object": "charge", "id": "ch_xxx"}`} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest("POST", "whook_token", []byte(body)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status should be 400 for body %q, but %d", body, w.Code)
+		}
+	}
+}
+
+func TestHandlerBodyTooLarge(t *testing.T) {
+	handler := NewHandler("whook_token")
+	handler.MaxBodyBytes = 16
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("POST", "whook_token", chargeEventJSON))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status should be 413, but %d", w.Code)
+	}
+}
+
+func TestHandlerCallbackError(t *testing.T) {
+	callbackErr := errors.New("failed")
+	var logged error
+	secondCalled := false
+	handler := NewHandler("whook_token", func(ctx context.Context, event *payjp.EventResponse) error {
+		return callbackErr
+	}, func(ctx context.Context, event *payjp.EventResponse) error {
+		secondCalled = true
+		return nil
+	})
+	handler.ErrorLog = func(r *http.Request, err error) {
+		logged = err
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("POST", "whook_token", chargeEventJSON))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status should be 500, but %d", w.Code)
+	}
+	if logged != callbackErr {
+		t.Errorf("ErrorLog should receive callback error, but %v", logged)
+	}
+	if secondCalled {
+		t.Error("callbacks after error should not be called")
+	}
+	if strings.Contains(w.Body.String(), "failed") {
+		t.Error("callback error should not be exposed in response")
+	}
+}